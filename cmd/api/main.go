@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/config"
@@ -24,13 +25,36 @@ func main() {
 
 	var teamRepository repository.TeamRepository = postgres.NewTeamRepository(db)
 	var userRepository repository.UserRepository = postgres.NewUserRepository(db)
-	var prRepository repository.PullRequestRepository = postgres.NewPullRequestRepository(db)
+	pullRequestRepository := postgres.NewPullRequestRepository(db)
+	var prRepository repository.PullRequestRepository = pullRequestRepository
+	var outboxRepository repository.OutboxRepository = pullRequestRepository
+	var ownershipRepository repository.OwnershipRepository = postgres.NewOwnershipRepository(db)
+	var webhookDeliveryRepository repository.WebhookDeliveryRepository = postgres.NewWebhookDeliveryRepository(db)
+	var notificationDeliveryRepository repository.NotificationDeliveryRepository = postgres.NewNotificationDeliveryRepository(db)
 
-	teamService := service.NewTeamService(teamRepository)
-	userService := service.NewUserService(userRepository)
-	prService := service.NewPullRequestService(prRepository, teamRepository, userRepository)
+	eventBus := service.NewEventBus()
+	notifications := service.NewNotificationDispatcher(cfg.Notifications, notificationDeliveryRepository)
+	eventBus.Subscribe(notifications.Dispatch)
+	relay := service.NewOutboxRelay(outboxRepository, eventBus, cfg.Notifications.RelayInterval)
+	go relay.Run(context.Background())
 
-	srv := http.New(cfg, teamService, userService, prService)
+	prService := service.NewPullRequestService(prRepository, teamRepository, userRepository,
+		service.WithSelectionConfig(cfg.Selection),
+		service.WithReviewsConfig(cfg.Reviews),
+		service.WithCapacityConfig(cfg.Capacity),
+		service.WithOwnershipRepository(ownershipRepository),
+	)
+	scheduler := service.NewSLAScheduler(prService, cfg.SLA)
+	go scheduler.Run(context.Background())
+	awayScheduler := service.NewAwayScheduler(prService, cfg.Away.CheckInterval)
+	go awayScheduler.Run(context.Background())
+
+	teamService := service.NewTeamService(teamRepository, service.WithReviewReleaser(prService))
+	userService := service.NewUserService(userRepository, teamRepository, service.WithReviewReassigner(prService))
+	ownershipService := service.NewOwnershipService(ownershipRepository)
+	webhookService := service.NewWebhookService(prService, userRepository, webhookDeliveryRepository, cfg.Webhooks.Users)
+
+	srv := http.New(cfg, teamService, userService, prService, ownershipService, webhookService, notifications)
 	err = srv.Run()
 	if err != nil {
 		log.Fatalf("Error server run: %v", err)
@@ -9,26 +9,104 @@ import (
 
 // Defines values for ErrorResponseErrorCode.
 const (
-	NOCANDIDATE ErrorResponseErrorCode = "NO_CANDIDATE"
-	NOTASSIGNED ErrorResponseErrorCode = "NOT_ASSIGNED"
-	NOTFOUND    ErrorResponseErrorCode = "NOT_FOUND"
-	PREXISTS    ErrorResponseErrorCode = "PR_EXISTS"
-	PRMERGED    ErrorResponseErrorCode = "PR_MERGED"
-	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
+	CONFLICT        ErrorResponseErrorCode = "CONFLICT"
+	NOCANDIDATE     ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTAPPROVED     ErrorResponseErrorCode = "NOT_APPROVED"
+	NOTASSIGNED     ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTFOUND        ErrorResponseErrorCode = "NOT_FOUND"
+	PREXISTS        ErrorResponseErrorCode = "PR_EXISTS"
+	PRCLOSED        ErrorResponseErrorCode = "PR_CLOSED"
+	PRDRAFT         ErrorResponseErrorCode = "PR_DRAFT"
+	PRMERGED        ErrorResponseErrorCode = "PR_MERGED"
+	TEAMARCHIVED    ErrorResponseErrorCode = "TEAM_ARCHIVED"
+	TEAMEXISTS      ErrorResponseErrorCode = "TEAM_EXISTS"
+	USEREXISTS      ErrorResponseErrorCode = "USER_EXISTS"
+	VERSIONCONFLICT ErrorResponseErrorCode = "VERSION_CONFLICT"
 )
 
 // Defines values for PullRequestStatus.
 const (
+	PullRequestStatusCLOSED PullRequestStatus = "CLOSED"
 	PullRequestStatusMERGED PullRequestStatus = "MERGED"
 	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
 )
 
+// Defines values for ReviewerAssignmentAction.
+const (
+	ReviewerAssignmentActionAway         ReviewerAssignmentAction = "away"
+	ReviewerAssignmentActionCreate       ReviewerAssignmentAction = "create"
+	ReviewerAssignmentActionDeactivation ReviewerAssignmentAction = "deactivation"
+	ReviewerAssignmentActionManual       ReviewerAssignmentAction = "manual"
+	ReviewerAssignmentActionQueue        ReviewerAssignmentAction = "queue"
+	ReviewerAssignmentActionReassign     ReviewerAssignmentAction = "reassign"
+	ReviewerAssignmentActionReopen       ReviewerAssignmentAction = "reopen"
+	ReviewerAssignmentActionTeamRemoval  ReviewerAssignmentAction = "team_removal"
+)
+
+// Defines values for ReviewDecisionDecision.
+const (
+	ReviewDecisionDecisionApproved         ReviewDecisionDecision = "approved"
+	ReviewDecisionDecisionChangesRequested ReviewDecisionDecision = "changes_requested"
+	ReviewDecisionDecisionCommented        ReviewDecisionDecision = "commented"
+)
+
+// Defines values for ReviewEscalationStage.
+const (
+	ReviewEscalationStageReassignment ReviewEscalationStage = "reassignment"
+	ReviewEscalationStageReminder     ReviewEscalationStage = "reminder"
+)
+
+// Defines values for Seniority.
+const (
+	SeniorityJunior Seniority = "junior"
+	SeniorityLead   Seniority = "lead"
+	SeniorityMiddle Seniority = "middle"
+	SenioritySenior Seniority = "senior"
+)
+
+// Defines values for TeamSelectionStrategy.
+const (
+	TeamSelectionStrategyHistoryAware TeamSelectionStrategy = "history_aware"
+	TeamSelectionStrategyLeastLoaded  TeamSelectionStrategy = "least_loaded"
+	TeamSelectionStrategyRandom       TeamSelectionStrategy = "random"
+	TeamSelectionStrategyRoundRobin   TeamSelectionStrategy = "round_robin"
+)
+
 // Defines values for PullRequestShortStatus.
 const (
+	PullRequestShortStatusCLOSED PullRequestShortStatus = "CLOSED"
 	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
 	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
 )
 
+// Defines values for GetPullRequestListParamsSortBy.
+const (
+	GetPullRequestListParamsSortByCreatedAt       GetPullRequestListParamsSortBy = "created_at"
+	GetPullRequestListParamsSortByPullRequestName GetPullRequestListParamsSortBy = "pull_request_name"
+)
+
+// Defines values for GetPullRequestListParamsOrder.
+const (
+	Asc  GetPullRequestListParamsOrder = "asc"
+	Desc GetPullRequestListParamsOrder = "desc"
+)
+
+// AwayPeriod defines model for AwayPeriod.
+type AwayPeriod struct {
+	// DelegateId Кому передаются ревью пользователя на время отсутствия
+	DelegateId *string   `json:"delegate_id,omitempty"`
+	EndsAt     time.Time `json:"ends_at"`
+
+	// HandOffReviews Передать открытые ревью в начале периода
+	HandOffReviews bool `json:"hand_off_reviews"`
+
+	// HandedOffAt Когда открытые ревью были переданы
+	HandedOffAt *time.Time `json:"handed_off_at,omitempty"`
+	Id          int64      `json:"id"`
+	StartsAt    time.Time  `json:"starts_at"`
+	UserId      string     `json:"user_id"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
@@ -40,16 +118,67 @@ type ErrorResponse struct {
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// NotificationDelivery defines model for NotificationDelivery.
+type NotificationDelivery struct {
+	// Attempt Номер попытки, начиная с 1
+	Attempt   int       `json:"attempt"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Error Ошибка соединения, если ответ не получен
+	Error     *string `json:"error,omitempty"`
+	EventId   string  `json:"event_id"`
+	EventType string  `json:"event_type"`
+
+	// StatusCode HTTP-статус ответа подписчика
+	StatusCode    *int   `json:"status_code,omitempty"`
+	SubscriberUrl string `json:"subscriber_url"`
+	Success       bool   `json:"success"`
+}
+
+// OwnershipRule defines model for OwnershipRule.
+type OwnershipRule struct {
+	// Owners Владельцы в формате CODEOWNERS: @user_id или @org/team_name
+	Owners  []string `json:"owners"`
+	Pattern string   `json:"pattern"`
+}
+
+// OwnershipRuleSet defines model for OwnershipRuleSet.
+type OwnershipRuleSet struct {
+	Repository string          `json:"repository"`
+	Rules      []OwnershipRule `json:"rules"`
+}
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
-	// AssignedReviewers user_id ╨╜╨░╨╖╨╜╨░╤З╨╡╨╜╨╜╤Л╤Е ╤А╨╡╨▓╤М╤О╨▓╨╡╤А╨╛╨▓ (0..2)
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	AuthorId          string            `json:"author_id"`
-	CreatedAt         *time.Time        `json:"createdAt"`
-	MergedAt          *time.Time        `json:"mergedAt"`
+	// AssignedReviewers user_id назначенных ревьюверов (0..required_reviewers команды)
+	AssignedReviewers []string `json:"assigned_reviewers"`
+	AuthorId          string   `json:"author_id"`
+
+	// ClosedAt Когда PR был закрыт без мержа
+	ClosedAt  *time.Time `json:"closedAt"`
+	CreatedAt *time.Time `json:"createdAt"`
+
+	// FallbackReviewers user_id ревьюверов, назначенных из резервных команд
+	FallbackReviewers *[]string `json:"fallback_reviewers,omitempty"`
+
+	// IsDraft Черновик: ревьюверы назначаются после перевода в readyForReview
+	IsDraft bool `json:"is_draft"`
+
+	// IsQueued Ревьюверов не хватило из-за лимитов открытых ревью, PR ждёт освобождения
+	IsQueued bool       `json:"is_queued"`
+	MergedAt *time.Time `json:"mergedAt"`
+
+	// OverflowReviewers user_id ревьюверов, назначенных сверх их лимита открытых ревью
+	OverflowReviewers *[]string         `json:"overflow_reviewers,omitempty"`
 	PullRequestId     string            `json:"pull_request_id"`
 	PullRequestName   string            `json:"pull_request_name"`
 	Status            PullRequestStatus `json:"status"`
+
+	// TeamName Команда, от имени которой открыт PR и из которой выбираются ревьюверы
+	TeamName string `json:"team_name"`
+
+	// Version Номер версии PR, увеличивается при каждом изменении
+	Version int `json:"version"`
 }
 
 // PullRequestStatus defines model for PullRequest.Status.
@@ -66,45 +195,294 @@ type PullRequestShort struct {
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
+// ReviewDecision defines model for ReviewDecision.
+type ReviewDecision struct {
+	// Decision Решение ревьювера
+	Decision      ReviewDecisionDecision `json:"decision"`
+	PullRequestId string                 `json:"pull_request_id"`
+	SubmittedAt   time.Time              `json:"submitted_at"`
+	UserId        string                 `json:"user_id"`
+}
+
+// ReviewDecisionDecision Решение ревьювера
+type ReviewDecisionDecision string
+
+// ReviewEscalation defines model for ReviewEscalation.
+type ReviewEscalation struct {
+	EscalatedAt time.Time `json:"escalated_at"`
+
+	// NewUserId Ревьювер, назначенный вместо просрочившего
+	NewUserId     *string `json:"new_user_id,omitempty"`
+	PullRequestId string  `json:"pull_request_id"`
+
+	// Stage Напоминание или переназначение
+	Stage  ReviewEscalationStage `json:"stage"`
+	UserId string                `json:"user_id"`
+}
+
+// ReviewEscalationStage Напоминание или переназначение
+type ReviewEscalationStage string
+
+// ReviewerAssignment defines model for ReviewerAssignment.
+type ReviewerAssignment struct {
+	// Action Действие, которым ревьювер был назначен
+	Action        ReviewerAssignmentAction `json:"action"`
+	AssignedAt    time.Time                `json:"assigned_at"`
+	PullRequestId string                   `json:"pull_request_id"`
+
+	// ReplacedUserId Ревьювер, которого заменил назначенный
+	ReplacedUserId *string `json:"replaced_user_id,omitempty"`
+
+	// UnassignedAt Когда ревьювер был снят с PR
+	UnassignedAt *time.Time `json:"unassigned_at,omitempty"`
+	UserId       string     `json:"user_id"`
+}
+
+// ReviewerAssignmentAction Действие, которым ревьювер был назначен
+type ReviewerAssignmentAction string
+
+// ReviewerDetails defines model for ReviewerDetails.
+type ReviewerDetails struct {
+	// Action Действие, которым ревьювер был назначен
+	Action     ReviewerAssignmentAction `json:"action"`
+	AssignedAt time.Time                `json:"assigned_at"`
+
+	// DecidedAt Когда ревьювер отправил последнее решение
+	DecidedAt *time.Time `json:"decided_at,omitempty"`
+
+	// Decision Последнее решение ревьювера
+	Decision *ReviewDecisionDecision `json:"decision,omitempty"`
+	IsActive bool                    `json:"is_active"`
+
+	// ReplacedUserId Ревьювер, которого заменил назначенный
+	ReplacedUserId *string `json:"replaced_user_id,omitempty"`
+
+	// TeamName Команда, от которой ревьювер проверяет PR
+	TeamName string `json:"team_name"`
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// Seniority Уровень ревьювера: junior < middle < senior < lead
+type Seniority string
+
 // Team defines model for Team.
 type Team struct {
-	Members  []TeamMember `json:"members"`
-	TeamName string       `json:"team_name"`
+	// FallbackTeams Команды, из которых по порядку добираются ревьюверы, если в команде не хватает активных участников
+	FallbackTeams *[]string `json:"fallback_teams,omitempty"`
+
+	// IsArchived Архивная команда не получает новых PR и не выдаёт ревьюверов
+	IsArchived bool `json:"is_archived"`
+
+	// MaxOpenReviews Сколько открытых ревью может быть у участника команды, если у него не задан свой лимит
+	MaxOpenReviews *int         `json:"max_open_reviews,omitempty"`
+	Members        []TeamMember `json:"members"`
+
+	// MinSeniorReviewers Сколько ревьюверов уровня senior или выше нужно на PR команды
+	MinSeniorReviewers *int `json:"min_senior_reviewers,omitempty"`
+
+	// RequiredReviewers Сколько ревьюверов назначать на PR команды (по умолчанию 2)
+	RequiredReviewers *int `json:"required_reviewers,omitempty"`
+
+	// ReviewTimeoutMinutes SLA ревьювера команды в минутах (по умолчанию sla.review_timeout)
+	ReviewTimeoutMinutes *int `json:"review_timeout_minutes,omitempty"`
+
+	// SelectionStrategy Стратегия выбора ревьюверов для команды
+	SelectionStrategy *TeamSelectionStrategy `json:"selection_strategy,omitempty"`
+	TeamName          string                 `json:"team_name"`
 }
 
+// TeamSelectionStrategy Стратегия выбора ревьюверов для команды
+type TeamSelectionStrategy string
+
 // TeamMember defines model for TeamMember.
 type TeamMember struct {
-	IsActive bool   `json:"is_active"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	IsActive bool `json:"is_active"`
+
+	// Seniority Уровень ревьювера: junior < middle < senior < lead
+	Seniority *Seniority `json:"seniority,omitempty"`
+	UserId    string     `json:"user_id"`
+	Username  string     `json:"username"`
 }
 
 // User defines model for User.
 type User struct {
-	IsActive bool   `json:"is_active"`
+	IsActive bool `json:"is_active"`
+
+	// MaxOpenReviews Сколько открытых ревью может быть у пользователя одновременно
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+
+	// Seniority Уровень ревьювера: junior < middle < senior < lead
+	Seniority *Seniority `json:"seniority,omitempty"`
+
+	// Tags Навыки пользователя, с которыми сверяются метки PR
+	Tags *[]string `json:"tags,omitempty"`
+
+	// TeamName Основная команда пользователя: первая из teams
 	TeamName string `json:"team_name"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+
+	// Teams Все команды пользователя в порядке вступления
+	Teams    []string `json:"teams"`
+	UserId   string   `json:"user_id"`
+	Username string   `json:"username"`
 }
 
 // TeamNameQuery defines model for TeamNameQuery.
 type TeamNameQuery = string
 
+// PullRequestIdQuery defines model for PullRequestIdQuery.
+type PullRequestIdQuery = string
+
+// RepositoryQuery defines model for RepositoryQuery.
+type RepositoryQuery = string
+
 // UserIdQuery defines model for UserIdQuery.
 type UserIdQuery = string
 
+// PostOwnershipDeleteJSONBody defines parameters for PostOwnershipDelete.
+type PostOwnershipDeleteJSONBody struct {
+	Repository string `json:"repository"`
+}
+
+// GetNotificationsDeliveriesParams defines parameters for GetNotificationsDeliveries.
+type GetNotificationsDeliveriesParams struct {
+	// EventId Показать только доставки этого события
+	EventId *string `form:"event_id,omitempty" json:"event_id,omitempty"`
+
+	// Limit Максимальное число записей (по умолчанию 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetOwnershipGetParams defines parameters for GetOwnershipGet.
+type GetOwnershipGetParams struct {
+	// Repository Имя репозитория
+	Repository RepositoryQuery `form:"repository" json:"repository"`
+}
+
+// PostOwnershipSetJSONBody defines parameters for PostOwnershipSet.
+type PostOwnershipSetJSONBody struct {
+	// Codeowners Содержимое файла CODEOWNERS
+	Codeowners *string          `json:"codeowners,omitempty"`
+	Repository string           `json:"repository"`
+	Rules      *[]OwnershipRule `json:"rules,omitempty"`
+}
+
+// GetPullRequestHistoryParams defines parameters for GetPullRequestHistory.
+type GetPullRequestHistoryParams struct {
+	// PullRequestId Идентификатор PR
+	PullRequestId PullRequestIdQuery `form:"pull_request_id" json:"pull_request_id"`
+}
+
+// GetPullRequestGetParams defines parameters for GetPullRequestGet.
+type GetPullRequestGetParams struct {
+	// PullRequestId Идентификатор PR
+	PullRequestId PullRequestIdQuery `form:"pull_request_id" json:"pull_request_id"`
+}
+
+// GetPullRequestListParams defines parameters for GetPullRequestList.
+type GetPullRequestListParams struct {
+	// AuthorId Показать только PR этого автора
+	AuthorId *string `form:"author_id,omitempty" json:"author_id,omitempty"`
+
+	// ReviewerId Показать только PR, где пользователь назначен ревьювером
+	ReviewerId *string `form:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+
+	// TeamName Показать только PR этой команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// Status Показать только PR с этим статусом
+	Status *PullRequestStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// CreatedFrom Созданы не раньше этого момента
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo Созданы раньше этого момента
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+
+	// MergedFrom Смержены не раньше этого момента
+	MergedFrom *time.Time `form:"merged_from,omitempty" json:"merged_from,omitempty"`
+
+	// MergedTo Смержены раньше этого момента
+	MergedTo *time.Time `form:"merged_to,omitempty" json:"merged_to,omitempty"`
+
+	// Name Подстрока названия PR без учёта регистра
+	Name *string `form:"name,omitempty" json:"name,omitempty"`
+
+	// SortBy Поле сортировки (по умолчанию created_at)
+	SortBy *GetPullRequestListParamsSortBy `form:"sort_by,omitempty" json:"sort_by,omitempty"`
+
+	// Order Направление сортировки (по умолчанию asc)
+	Order *GetPullRequestListParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// Cursor Курсор следующей страницы из предыдущего ответа
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Размер страницы (по умолчанию 20)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetPullRequestListParamsSortBy defines parameters for GetPullRequestList.
+type GetPullRequestListParamsSortBy string
+
+// GetPullRequestListParamsOrder defines parameters for GetPullRequestList.
+type GetPullRequestListParamsOrder string
+
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
-	AuthorId        string `json:"author_id"`
-	PullRequestId   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
+	AuthorId string `json:"author_id"`
+
+	// ChangedFiles Пути изменённых файлов для маршрутизации по CODEOWNERS
+	ChangedFiles *[]string `json:"changed_files,omitempty"`
+
+	// Draft Создать черновик без ревьюверов
+	Draft *bool `json:"draft,omitempty"`
+
+	// Labels Метки PR, по которым предпочитаются ревьюверы с подходящими навыками
+	Labels          *[]string `json:"labels,omitempty"`
+	PullRequestId   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	Repository      *string   `json:"repository,omitempty"`
+
+	// TeamName Команда PR, если автор состоит в нескольких (по умолчанию основная команда автора)
+	TeamName *string `json:"team_name,omitempty"`
+}
+
+// PostPullRequestCloseJSONBody defines parameters for PostPullRequestClose.
+type PostPullRequestCloseJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
 }
 
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
 type PostPullRequestMergeJSONBody struct {
+	// AdminOverride Смержить без требуемого числа одобрений
+	AdminOverride *bool  `json:"admin_override,omitempty"`
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestReopenJSONBody defines parameters for PostPullRequestReopen.
+type PostPullRequestReopenJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// PostPullRequestReadyForReviewJSONBody defines parameters for PostPullRequestReadyForReview.
+type PostPullRequestReadyForReviewJSONBody struct {
+	// ChangedFiles Пути изменённых файлов для маршрутизации по CODEOWNERS
+	ChangedFiles *[]string `json:"changed_files,omitempty"`
+
+	// Labels Метки PR, по которым предпочитаются ревьюверы с подходящими навыками
+	Labels        *[]string `json:"labels,omitempty"`
+	PullRequestId string    `json:"pull_request_id"`
+	Repository    *string   `json:"repository,omitempty"`
+}
+
+// PostPullRequestReviewJSONBody defines parameters for PostPullRequestReview.
+type PostPullRequestReviewJSONBody struct {
+	// Decision Решение ревьювера
+	Decision      ReviewDecisionDecision `json:"decision"`
+	PullRequestId string                 `json:"pull_request_id"`
+	UserId        string                 `json:"user_id"`
+}
+
 // PostPullRequestReassignJSONBody defines parameters for PostPullRequestReassign.
 type PostPullRequestReassignJSONBody struct {
 	OldUserId     string `json:"old_user_id"`
@@ -123,24 +501,197 @@ type GetUsersGetReviewParams struct {
 	UserId UserIdQuery `form:"user_id" json:"user_id"`
 }
 
+// GetUsersAwayListParams defines parameters for GetUsersAwayList.
+type GetUsersAwayListParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// GetUsersGetParams defines parameters for GetUsersGet.
+type GetUsersGetParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// GetUsersListParams defines parameters for GetUsersList.
+type GetUsersListParams struct {
+	// TeamName Показать только участников этой команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// IsActive Показать только активных или только неактивных пользователей
+	IsActive *bool `form:"is_active,omitempty" json:"is_active,omitempty"`
+
+	// Tag Показать только пользователей с этим навыком
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+}
+
+// PostTeamAddMembersJSONBody defines parameters for PostTeamAddMembers.
+type PostTeamAddMembersJSONBody struct {
+	Members  []TeamMember `json:"members"`
+	TeamName string       `json:"team_name"`
+}
+
+// PostTeamArchiveJSONBody defines parameters for PostTeamArchive.
+type PostTeamArchiveJSONBody struct {
+	TeamName string `json:"team_name"`
+}
+
+// PostTeamDeleteJSONBody defines parameters for PostTeamDelete.
+type PostTeamDeleteJSONBody struct {
+	TeamName string `json:"team_name"`
+}
+
+// PostTeamRemoveMembersJSONBody defines parameters for PostTeamRemoveMembers.
+type PostTeamRemoveMembersJSONBody struct {
+	TeamName string   `json:"team_name"`
+	UserIds  []string `json:"user_ids"`
+}
+
+// PostTeamRenameJSONBody defines parameters for PostTeamRename.
+type PostTeamRenameJSONBody struct {
+	NewTeamName string `json:"new_team_name"`
+	TeamName    string `json:"team_name"`
+}
+
+// PostTeamUpdateJSONBody defines parameters for PostTeamUpdate.
+type PostTeamUpdateJSONBody struct {
+	FallbackTeams        *[]string              `json:"fallback_teams,omitempty"`
+	MaxOpenReviews       *int                   `json:"max_open_reviews,omitempty"`
+	MinSeniorReviewers   *int                   `json:"min_senior_reviewers,omitempty"`
+	RequiredReviewers    *int                   `json:"required_reviewers,omitempty"`
+	ReviewTimeoutMinutes *int                   `json:"review_timeout_minutes,omitempty"`
+	SelectionStrategy    *TeamSelectionStrategy `json:"selection_strategy,omitempty"`
+	TeamName             string                 `json:"team_name"`
+}
+
+// PostUsersAwayAddJSONBody defines parameters for PostUsersAwayAdd.
+type PostUsersAwayAddJSONBody struct {
+	// DelegateId Кому передаются ревью пользователя на время отсутствия
+	DelegateId *string   `json:"delegate_id,omitempty"`
+	EndsAt     time.Time `json:"ends_at"`
+
+	// HandOffReviews Передать открытые ревью в начале периода
+	HandOffReviews *bool     `json:"hand_off_reviews,omitempty"`
+	StartsAt       time.Time `json:"starts_at"`
+	UserId         string    `json:"user_id"`
+}
+
+// PostUsersAwayDeleteJSONBody defines parameters for PostUsersAwayDelete.
+type PostUsersAwayDeleteJSONBody struct {
+	Id int64 `json:"id"`
+}
+
+// PostUsersCreateJSONBody defines parameters for PostUsersCreate.
+type PostUsersCreateJSONBody struct {
+	// IsActive По умолчанию true
+	IsActive *bool `json:"is_active,omitempty"`
+
+	// MaxOpenReviews Сколько открытых ревью может быть у пользователя одновременно
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+
+	// Seniority Уровень ревьювера
+	Seniority *Seniority `json:"seniority,omitempty"`
+
+	// Tags Навыки пользователя
+	Tags *[]string `json:"tags,omitempty"`
+
+	// Teams Команды пользователя
+	Teams    *[]string `json:"teams,omitempty"`
+	UserId   string    `json:"user_id"`
+	Username string    `json:"username"`
+}
+
+// PostUsersOffboardJSONBody defines parameters for PostUsersOffboard.
+type PostUsersOffboardJSONBody struct {
+	UserId string `json:"user_id"`
+}
+
+// PostUsersUpdateJSONBody defines parameters for PostUsersUpdate.
+type PostUsersUpdateJSONBody struct {
+	// MaxOpenReviews Сколько открытых ревью может быть у пользователя одновременно
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+
+	// Seniority Уровень ревьювера
+	Seniority *Seniority `json:"seniority,omitempty"`
+
+	// Tags Новый полный список навыков пользователя
+	Tags *[]string `json:"tags,omitempty"`
+
+	// Teams Новый полный список команд пользователя
+	Teams    *[]string `json:"teams,omitempty"`
+	UserId   string    `json:"user_id"`
+	Username *string   `json:"username,omitempty"`
+}
+
 // PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
 type PostUsersSetIsActiveJSONBody struct {
 	IsActive bool   `json:"is_active"`
 	UserId   string `json:"user_id"`
 }
 
+// PostOwnershipDeleteJSONRequestBody defines body for PostOwnershipDelete for application/json ContentType.
+type PostOwnershipDeleteJSONRequestBody PostOwnershipDeleteJSONBody
+
+// PostOwnershipSetJSONRequestBody defines body for PostOwnershipSet for application/json ContentType.
+type PostOwnershipSetJSONRequestBody PostOwnershipSetJSONBody
+
 // PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
 type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
 
+// PostPullRequestCloseJSONRequestBody defines body for PostPullRequestClose for application/json ContentType.
+type PostPullRequestCloseJSONRequestBody PostPullRequestCloseJSONBody
+
 // PostPullRequestMergeJSONRequestBody defines body for PostPullRequestMerge for application/json ContentType.
 type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
 
+// PostPullRequestReopenJSONRequestBody defines body for PostPullRequestReopen for application/json ContentType.
+type PostPullRequestReopenJSONRequestBody PostPullRequestReopenJSONBody
+
+// PostPullRequestReadyForReviewJSONRequestBody defines body for PostPullRequestReadyForReview for application/json ContentType.
+type PostPullRequestReadyForReviewJSONRequestBody PostPullRequestReadyForReviewJSONBody
+
+// PostPullRequestReviewJSONRequestBody defines body for PostPullRequestReview for application/json ContentType.
+type PostPullRequestReviewJSONRequestBody PostPullRequestReviewJSONBody
+
 // PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
 type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
 
 // PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
 type PostTeamAddJSONRequestBody = Team
 
+// PostTeamAddMembersJSONRequestBody defines body for PostTeamAddMembers for application/json ContentType.
+type PostTeamAddMembersJSONRequestBody PostTeamAddMembersJSONBody
+
+// PostTeamArchiveJSONRequestBody defines body for PostTeamArchive for application/json ContentType.
+type PostTeamArchiveJSONRequestBody PostTeamArchiveJSONBody
+
+// PostTeamDeleteJSONRequestBody defines body for PostTeamDelete for application/json ContentType.
+type PostTeamDeleteJSONRequestBody PostTeamDeleteJSONBody
+
+// PostTeamRemoveMembersJSONRequestBody defines body for PostTeamRemoveMembers for application/json ContentType.
+type PostTeamRemoveMembersJSONRequestBody PostTeamRemoveMembersJSONBody
+
+// PostTeamRenameJSONRequestBody defines body for PostTeamRename for application/json ContentType.
+type PostTeamRenameJSONRequestBody PostTeamRenameJSONBody
+
+// PostTeamUpdateJSONRequestBody defines body for PostTeamUpdate for application/json ContentType.
+type PostTeamUpdateJSONRequestBody PostTeamUpdateJSONBody
+
+// PostUsersAwayAddJSONRequestBody defines body for PostUsersAwayAdd for application/json ContentType.
+type PostUsersAwayAddJSONRequestBody PostUsersAwayAddJSONBody
+
+// PostUsersAwayDeleteJSONRequestBody defines body for PostUsersAwayDelete for application/json ContentType.
+type PostUsersAwayDeleteJSONRequestBody PostUsersAwayDeleteJSONBody
+
+// PostUsersCreateJSONRequestBody defines body for PostUsersCreate for application/json ContentType.
+type PostUsersCreateJSONRequestBody PostUsersCreateJSONBody
+
+// PostUsersOffboardJSONRequestBody defines body for PostUsersOffboard for application/json ContentType.
+type PostUsersOffboardJSONRequestBody PostUsersOffboardJSONBody
+
+// PostUsersUpdateJSONRequestBody defines body for PostUsersUpdate for application/json ContentType.
+type PostUsersUpdateJSONRequestBody PostUsersUpdateJSONBody
+
 // PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
 type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
 
@@ -151,6 +702,7 @@ type Statistics struct {
 	ByStatus         struct {
 		Open   int `json:"open"`
 		Merged int `json:"merged"`
+		Closed int `json:"closed"`
 	} `json:"by_status"`
 }
 
@@ -164,6 +716,8 @@ type BatchDeactivateRequest struct {
 type BatchDeactivateResponse struct {
 	DeactivatedCount int `json:"deactivated_count"`
 	ReassignedCount  int `json:"reassigned_count"`
+	OverflowCount    int `json:"overflow_count"`
+	QueuedCount      int `json:"queued_count"`
 	Errors           []struct {
 		UserID string `json:"user_id"`
 		Error  string `json:"error"`
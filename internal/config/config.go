@@ -2,13 +2,21 @@ package config
 
 import (
 	"errors"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
+	Server        ServerConfig        `mapstructure:"server"`
+	Database      DatabaseConfig      `mapstructure:"database"`
+	Selection     SelectionConfig     `mapstructure:"selection"`
+	Webhooks      WebhooksConfig      `mapstructure:"webhooks"`
+	Notifications NotificationsConfig `mapstructure:"notifications"`
+	Reviews       ReviewsConfig       `mapstructure:"reviews"`
+	SLA           SLAConfig           `mapstructure:"sla"`
+	Away          AwayConfig          `mapstructure:"away"`
+	Capacity      CapacityConfig      `mapstructure:"capacity"`
 }
 
 type ServerConfig struct {
@@ -25,6 +33,51 @@ type DatabaseConfig struct {
 	SSLMode  string `mapstructure:"sslmode"`
 }
 
+type SelectionConfig struct {
+	DefaultStrategy string            `mapstructure:"default_strategy"`
+	Teams           map[string]string `mapstructure:"teams"`
+	RequireExpert   bool              `mapstructure:"require_expert"`
+	HistoryWindow   time.Duration     `mapstructure:"history_window"`
+}
+
+type ReviewsConfig struct {
+	RequiredApprovals int `mapstructure:"required_approvals"`
+}
+
+type SLAConfig struct {
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+	ReviewTimeout time.Duration `mapstructure:"review_timeout"`
+}
+
+type CapacityConfig struct {
+	MaxOpenReviews int    `mapstructure:"max_open_reviews"`
+	WhenFull       string `mapstructure:"when_full"`
+}
+
+type AwayConfig struct {
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+}
+
+type WebhooksConfig struct {
+	GitHubSecret string            `mapstructure:"github_secret"`
+	GitLabSecret string            `mapstructure:"gitlab_secret"`
+	Users        map[string]string `mapstructure:"users"`
+}
+
+type NotificationsConfig struct {
+	MaxAttempts    int                            `mapstructure:"max_attempts"`
+	InitialBackoff time.Duration                  `mapstructure:"initial_backoff"`
+	Timeout        time.Duration                  `mapstructure:"timeout"`
+	RelayInterval  time.Duration                  `mapstructure:"relay_interval"`
+	Subscribers    []NotificationSubscriberConfig `mapstructure:"subscribers"`
+}
+
+type NotificationSubscriberConfig struct {
+	URL    string   `mapstructure:"url"`
+	Secret string   `mapstructure:"secret"`
+	Events []string `mapstructure:"events"`
+}
+
 func Load(path string) (*Config, error) {
 	v := viper.New()
 	v.SetConfigName("config")
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of domain errors. Every *Error matches exactly one of them with
+// errors.Is, which is what callers should branch on.
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrConflict           = errors.New("conflict")
+	ErrPreconditionFailed = errors.New("precondition failed")
+	ErrNoCandidate        = errors.New("no candidate")
+	ErrInvalid            = errors.New("invalid request")
+)
+
+// Codes reported in api.ErrorResponse.
+const (
+	CodeNotFound        = "NOT_FOUND"
+	CodeInvalidRequest  = "INVALID_REQUEST"
+	CodeConflict        = "CONFLICT"
+	CodeTeamExists      = "TEAM_EXISTS"
+	CodePRExists        = "PR_EXISTS"
+	CodePRMerged        = "PR_MERGED"
+	CodePRClosed        = "PR_CLOSED"
+	CodePRDraft         = "PR_DRAFT"
+	CodeNotApproved     = "NOT_APPROVED"
+	CodeNotAssigned     = "NOT_ASSIGNED"
+	CodeNoCandidate     = "NO_CANDIDATE"
+	CodeVersionConflict = "VERSION_CONFLICT"
+	CodeTeamArchived    = "TEAM_ARCHIVED"
+	CodeUserExists      = "USER_EXISTS"
+)
+
+// Error is a failure the client can act on. Kind is one of the sentinels
+// above, Code and Message are what the API reports; Err is the cause and is
+// only logged.
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+// Is matches the kind of e and any *Error with the same code and message,
+// which keeps the declared errors below matching after Wrap.
+func (e *Error) Is(target error) bool {
+	if target == e.Kind {
+		return true
+	}
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code && t.Message == e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func NotFound(message string) *Error {
+	return &Error{Kind: ErrNotFound, Code: CodeNotFound, Message: message}
+}
+
+func Conflict(code string, message string) *Error {
+	return &Error{Kind: ErrConflict, Code: code, Message: message}
+}
+
+func PreconditionFailed(code string, message string) *Error {
+	return &Error{Kind: ErrPreconditionFailed, Code: code, Message: message}
+}
+
+func Invalid(message string) *Error {
+	return &Error{Kind: ErrInvalid, Code: CodeInvalidRequest, Message: message}
+}
+
+// Wrap returns a copy of e that carries err as its cause, so both e and
+// err still match with errors.Is.
+func Wrap(e *Error, err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+var (
+	ErrTeamNotFound       = NotFound("team not found")
+	ErrUserNotFound       = NotFound("user not found")
+	ErrAuthorNotFound     = NotFound("author not found")
+	ErrAuthorHasNoTeam    = NotFound("author has no team")
+	ErrPRNotFound         = NotFound("PR not found")
+	ErrReviewerNotFound   = NotFound("reviewer not found")
+	ErrOwnershipNotFound  = NotFound("ownership rules not found")
+	ErrAwayPeriodNotFound = NotFound("away period not found")
+	ErrDelegateNotFound   = NotFound("delegate not found")
+
+	ErrTeamExists       = Conflict(CodeTeamExists, "team_name already exists")
+	ErrPRExists         = Conflict(CodePRExists, "PR id already exists")
+	ErrUserExists       = Conflict(CodeUserExists, "user_id already exists")
+	ErrDeliveryExists   = Conflict(CodeConflict, "delivery already exists")
+	ErrVersionConflict  = Conflict(CodeVersionConflict, "PR was modified concurrently, retry the request")
+	ErrUniqueViolation  = Conflict(CodeConflict, "resource already exists")
+	ErrForeignViolation = Conflict(CodeConflict, "referenced resource does not exist")
+
+	ErrPRMerged        = PreconditionFailed(CodePRMerged, "cannot reassign on merged PR")
+	ErrPRAlreadyMerged = PreconditionFailed(CodePRMerged, "PR is already merged")
+	ErrPRClosed        = PreconditionFailed(CodePRClosed, "PR is closed, reopen it first")
+	ErrPRDraft         = PreconditionFailed(CodePRDraft, "PR is a draft, mark it ready for review first")
+	ErrNotApproved     = PreconditionFailed(CodeNotApproved, "PR does not have the required approvals")
+	ErrNotAssigned     = PreconditionFailed(CodeNotAssigned, "reviewer is not assigned to this PR")
+	ErrTeamArchived    = PreconditionFailed(CodeTeamArchived, "team is archived")
+
+	ErrNoReplacementCandidate = &Error{Kind: ErrNoCandidate, Code: CodeNoCandidate, Message: "no active replacement candidate in team"}
+	ErrNoActiveMembers        = &Error{Kind: ErrNoCandidate, Code: CodeNoCandidate, Message: "no active team members available for reassignment"}
+	ErrNoSeniorCandidate      = &Error{Kind: ErrNoCandidate, Code: CodeNoCandidate, Message: "no active senior replacement candidate"}
+
+	ErrInvalidRequiredReviewers = Invalid("required_reviewers must be at least 1")
+	ErrInvalidSelectionStrategy = Invalid("unknown selection_strategy")
+	ErrInvalidFallbackTeams     = Invalid("fallback_teams must list other existing teams once")
+	ErrRepositoryRequired       = Invalid("repository is required")
+	ErrNoMembers                = Invalid("members cannot be empty")
+	ErrNoUserIDs                = Invalid("user_ids cannot be empty")
+	ErrNotTeamMember            = Invalid("user_ids must be members of the team")
+	ErrAuthorNotTeamMember      = Invalid("author is not a member of team_name")
+	ErrInvalidUser              = Invalid("user_id and username are required")
+	ErrInvalidTeamName          = Invalid("new_team_name must be a new non-empty name")
+	ErrInvalidStatus            = Invalid("unknown status")
+	ErrInvalidSort              = Invalid("sort_by must be created_at or pull_request_name and order asc or desc")
+	ErrInvalidCursor            = Invalid("cursor is malformed")
+	ErrInvalidDecision          = Invalid("decision must be approved, changes_requested or commented")
+	ErrInvalidAwayPeriod        = Invalid("ends_at must be after starts_at")
+	ErrInvalidDelegate          = Invalid("delegate_id must be another user")
+	ErrInvalidMaxOpenReviews    = Invalid("max_open_reviews must be at least 1")
+	ErrInvalidTags              = Invalid("tags must not be empty")
+	ErrInvalidSeniority         = Invalid("seniority must be junior, middle, senior or lead")
+	ErrInvalidMinSeniors        = Invalid("min_senior_reviewers must not be negative")
+	ErrInvalidReviewTimeout     = Invalid("review_timeout_minutes must be at least 1")
+)
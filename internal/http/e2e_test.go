@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/config"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/http/handler"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository/inmemory"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/service"
@@ -22,10 +23,12 @@ func SetupTestServer() *httptest.Server {
 	userRepo := inmemory.NewUserRepository()
 
 	teamService := service.NewTeamService(teamRepo)
-	userService := service.NewUserService(userRepo)
+	userService := service.NewUserService(userRepo, teamRepo)
 	prService := service.NewPullRequestService(prRepo, teamRepo, userRepo)
 
-	h := handler.NewServerHandler(teamService, userService, prService)
+	ownershipService := service.NewOwnershipService(inmemory.NewOwnershipRepository())
+	notifications := service.NewNotificationDispatcher(config.NotificationsConfig{}, inmemory.NewNotificationDeliveryRepository())
+	h := handler.NewServerHandler(teamService, userService, prService, ownershipService, notifications)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("GET /stats", h.GetStats)
@@ -105,9 +108,11 @@ func TestE2EBatchDeactivation(t *testing.T) {
 	}
 
 	teamService := service.NewTeamService(teamRepo)
-	userService := service.NewUserService(userRepo)
+	userService := service.NewUserService(userRepo, teamRepo)
 	prService := service.NewPullRequestService(prRepo, teamRepo, userRepo)
-	h := handler.NewServerHandler(teamService, userService, prService)
+	ownershipService := service.NewOwnershipService(inmemory.NewOwnershipRepository())
+	notifications := service.NewNotificationDispatcher(config.NotificationsConfig{}, inmemory.NewNotificationDeliveryRepository())
+	h := handler.NewServerHandler(teamService, userService, prService, ownershipService, notifications)
 
 	t.Run("deactivate_users", func(t *testing.T) {
 		body := api.PostUsersDeactivateBatchJSONRequestBody{
@@ -180,9 +185,11 @@ func TestE2EErrorHandling(t *testing.T) {
 	userRepo := inmemory.NewUserRepository()
 
 	teamService := service.NewTeamService(teamRepo)
-	userService := service.NewUserService(userRepo)
+	userService := service.NewUserService(userRepo, teamRepo)
 	prService := service.NewPullRequestService(prRepo, teamRepo, userRepo)
-	h := handler.NewServerHandler(teamService, userService, prService)
+	ownershipService := service.NewOwnershipService(inmemory.NewOwnershipRepository())
+	notifications := service.NewNotificationDispatcher(config.NotificationsConfig{}, inmemory.NewNotificationDeliveryRepository())
+	h := handler.NewServerHandler(teamService, userService, prService, ownershipService, notifications)
 
 	t.Run("deactivate_nonexistent_team", func(t *testing.T) {
 		body := api.PostUsersDeactivateBatchJSONRequestBody{
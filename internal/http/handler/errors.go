@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+)
+
+// writeServiceError reports an error returned by a service. Domain errors
+// carry their own code and message; anything else is logged and answered
+// with 500.
+func writeServiceError(w http.ResponseWriter, err error, action string) {
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) {
+		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+		slog.Error("Error "+action, "error", err)
+		return
+	}
+	writeError(w, errorStatus(domainErr), domainErr.Code, domainErr.Message)
+}
+
+func errorStatus(err *domain.Error) int {
+	// The API has always answered TEAM_EXISTS with 400.
+	if err.Code == domain.CodeTeamExists {
+		return http.StatusBadRequest
+	}
+
+	switch err.Kind {
+	case domain.ErrNotFound:
+		return http.StatusNotFound
+	case domain.ErrInvalid:
+		return http.StatusBadRequest
+	case domain.ErrConflict, domain.ErrPreconditionFailed, domain.ErrNoCandidate:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -6,20 +6,32 @@ import (
 	"net/http"
 
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/service"
 )
 
 type ServerHandler struct {
-	teamService *service.TeamService
-	userService *service.UserService
-	prService   *service.PullRequestService
+	teamService      *service.TeamService
+	userService      *service.UserService
+	prService        *service.PullRequestService
+	ownershipService *service.OwnershipService
+	notifications    *service.NotificationDispatcher
 }
 
-func NewServerHandler(teamService *service.TeamService, userService *service.UserService, prService *service.PullRequestService) *ServerHandler {
+func NewServerHandler(
+	teamService *service.TeamService,
+	userService *service.UserService,
+	prService *service.PullRequestService,
+	ownershipService *service.OwnershipService,
+	notifications *service.NotificationDispatcher,
+) *ServerHandler {
 	return &ServerHandler{
-		teamService: teamService,
-		userService: userService,
-		prService:   prService,
+		teamService:      teamService,
+		userService:      userService,
+		prService:        prService,
+		ownershipService: ownershipService,
+		notifications:    notifications,
 	}
 }
 
@@ -45,6 +57,12 @@ func writeError(w http.ResponseWriter, status int, code string, message string)
 	writeJSON(w, status, response)
 }
 
+// HandleParamError answers requests whose query parameters are missing or
+// malformed.
+func (h *ServerHandler) HandleParamError(w http.ResponseWriter, _ *http.Request, err error) {
+	writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+}
+
 func (h *ServerHandler) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
 	var req api.Team
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -54,12 +72,7 @@ func (h *ServerHandler) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
 
 	err := h.teamService.AddTeam(&req)
 	if err != nil {
-		if err.Error() == "team already exists" {
-			writeError(w, http.StatusBadRequest, "TEAM_EXISTS", "team_name already exists")
-		} else {
-			writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
-			slog.Error("Error adding team", "error", err)
-		}
+		writeServiceError(w, err, "adding team")
 		return
 	}
 
@@ -78,13 +91,129 @@ func (h *ServerHandler) GetTeamGet(w http.ResponseWriter, _ *http.Request, param
 
 	team, err := h.teamService.GetTeamByName(teamName)
 	if err != nil {
-		writeError(w, http.StatusNotFound, "NOT_FOUND", "Team not found")
+		writeServiceError(w, err, "getting team")
 		return
 	}
 
 	writeJSON(w, http.StatusOK, team)
 }
 
+func (h *ServerHandler) PostTeamUpdate(w http.ResponseWriter, r *http.Request) {
+	var req api.PostTeamUpdateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	team, err := h.teamService.UpdateTeamSettings(req)
+	if err != nil {
+		writeServiceError(w, err, "updating team")
+		return
+	}
+
+	response := map[string]interface{}{
+		"team": team,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) PostTeamAddMembers(w http.ResponseWriter, r *http.Request) {
+	var req api.PostTeamAddMembersJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	team, err := h.teamService.AddMembers(req.TeamName, req.Members)
+	if err != nil {
+		writeServiceError(w, err, "adding team members")
+		return
+	}
+
+	response := map[string]interface{}{
+		"team": team,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) PostTeamRemoveMembers(w http.ResponseWriter, r *http.Request) {
+	var req api.PostTeamRemoveMembersJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	team, reassigned, err := h.teamService.RemoveMembers(req.TeamName, req.UserIds)
+	if err != nil {
+		writeServiceError(w, err, "removing team members")
+		return
+	}
+
+	response := map[string]interface{}{
+		"team":             team,
+		"reassigned_count": reassigned,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) PostTeamRename(w http.ResponseWriter, r *http.Request) {
+	var req api.PostTeamRenameJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	team, err := h.teamService.RenameTeam(req.TeamName, req.NewTeamName)
+	if err != nil {
+		writeServiceError(w, err, "renaming team")
+		return
+	}
+
+	response := map[string]interface{}{
+		"team": team,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) PostTeamArchive(w http.ResponseWriter, r *http.Request) {
+	var req api.PostTeamArchiveJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	team, err := h.teamService.ArchiveTeam(req.TeamName)
+	if err != nil {
+		writeServiceError(w, err, "archiving team")
+		return
+	}
+
+	response := map[string]interface{}{
+		"team": team,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) PostTeamDelete(w http.ResponseWriter, r *http.Request) {
+	var req api.PostTeamDeleteJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	reassigned, err := h.teamService.DeleteTeam(req.TeamName)
+	if err != nil {
+		writeServiceError(w, err, "deleting team")
+		return
+	}
+
+	response := map[string]interface{}{
+		"team_name":        req.TeamName,
+		"reassigned_count": reassigned,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
 func (h *ServerHandler) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		UserID   string `json:"user_id"`
@@ -97,7 +226,7 @@ func (h *ServerHandler) PostUsersSetIsActive(w http.ResponseWriter, r *http.Requ
 
 	user, err := h.userService.SetUserStatus(req.UserID, req.IsActive)
 	if err != nil {
-		writeError(w, http.StatusNotFound, "NOT_FOUND", "User not found")
+		writeServiceError(w, err, "setting user status")
 		return
 	}
 
@@ -107,11 +236,179 @@ func (h *ServerHandler) PostUsersSetIsActive(w http.ResponseWriter, r *http.Requ
 	writeJSON(w, http.StatusOK, response)
 }
 
+func (h *ServerHandler) PostUsersCreate(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersCreateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	user := api.User{UserId: req.UserId, Username: req.Username, IsActive: true, MaxOpenReviews: req.MaxOpenReviews, Tags: req.Tags,
+		Seniority: req.Seniority}
+	if req.IsActive != nil {
+		user.IsActive = *req.IsActive
+	}
+	if req.Teams != nil {
+		user.Teams = *req.Teams
+	}
+
+	created, err := h.userService.CreateUser(user)
+	if err != nil {
+		writeServiceError(w, err, "creating user")
+		return
+	}
+
+	response := map[string]interface{}{
+		"user": created,
+	}
+	writeJSON(w, http.StatusCreated, response)
+}
+
+func (h *ServerHandler) GetUsersGet(w http.ResponseWriter, _ *http.Request, params api.GetUsersGetParams) {
+	if params.UserId == "" {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id parameter is required")
+		return
+	}
+
+	user, err := h.userService.GetUserByID(params.UserId)
+	if err != nil {
+		writeServiceError(w, err, "getting user")
+		return
+	}
+
+	response := map[string]interface{}{
+		"user": user,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) GetUsersList(w http.ResponseWriter, _ *http.Request, params api.GetUsersListParams) {
+	teamName := ""
+	if params.TeamName != nil {
+		teamName = *params.TeamName
+	}
+
+	users, err := h.userService.ListUsers(teamName, valueOf(params.Tag), params.IsActive)
+	if err != nil {
+		writeServiceError(w, err, "listing users")
+		return
+	}
+
+	response := map[string]interface{}{
+		"users": users,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) PostUsersUpdate(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersUpdateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	user, reassigned, err := h.userService.UpdateUser(req)
+	if err != nil {
+		writeServiceError(w, err, "updating user")
+		return
+	}
+
+	response := map[string]interface{}{
+		"user":             user,
+		"reassigned_count": reassigned,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) PostUsersOffboard(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersOffboardJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	user, reassigned, err := h.userService.OffboardUser(req.UserId)
+	if err != nil {
+		writeServiceError(w, err, "offboarding user")
+		return
+	}
+
+	response := map[string]interface{}{
+		"user":             user,
+		"reassigned_count": reassigned,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) PostUsersAwayAdd(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersAwayAddJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	period, err := h.userService.AddAwayPeriod(api.AwayPeriod{
+		UserId:         req.UserId,
+		StartsAt:       req.StartsAt,
+		EndsAt:         req.EndsAt,
+		DelegateId:     req.DelegateId,
+		HandOffReviews: valueOf(req.HandOffReviews),
+	})
+	if err != nil {
+		writeServiceError(w, err, "adding away period")
+		return
+	}
+
+	response := map[string]interface{}{
+		"away_period": period,
+	}
+	writeJSON(w, http.StatusCreated, response)
+}
+
+func (h *ServerHandler) GetUsersAwayList(w http.ResponseWriter, _ *http.Request, params api.GetUsersAwayListParams) {
+	if params.UserId == "" {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id parameter is required")
+		return
+	}
+
+	periods, err := h.userService.ListAwayPeriods(params.UserId)
+	if err != nil {
+		writeServiceError(w, err, "listing away periods")
+		return
+	}
+
+	response := map[string]interface{}{
+		"user_id":      params.UserId,
+		"away_periods": periods,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) PostUsersAwayDelete(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersAwayDeleteJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	if err := h.userService.DeleteAwayPeriod(req.Id); err != nil {
+		writeServiceError(w, err, "deleting away period")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *ServerHandler) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		PullRequestID   string `json:"pull_request_id"`
-		PullRequestName string `json:"pull_request_name"`
-		AuthorID        string `json:"author_id"`
+		PullRequestID   string   `json:"pull_request_id"`
+		PullRequestName string   `json:"pull_request_name"`
+		AuthorID        string   `json:"author_id"`
+		TeamName        string   `json:"team_name"`
+		Repository      string   `json:"repository"`
+		ChangedFiles    []string `json:"changed_files"`
+		Labels          []string `json:"labels"`
+		Draft           bool     `json:"draft"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
@@ -119,7 +416,7 @@ func (h *ServerHandler) PostPullRequestCreate(w http.ResponseWriter, r *http.Req
 	}
 
 	if prExists, _ := h.prService.FindPRByID(req.PullRequestID); prExists != nil {
-		writeError(w, http.StatusConflict, "PR_EXISTS", "PR id already exists")
+		writeServiceError(w, domain.ErrPRExists, "creating PR")
 		return
 	}
 
@@ -127,16 +424,17 @@ func (h *ServerHandler) PostPullRequestCreate(w http.ResponseWriter, r *http.Req
 		PullRequestId:   req.PullRequestID,
 		PullRequestName: req.PullRequestName,
 		AuthorId:        req.AuthorID,
+		TeamName:        req.TeamName,
 	}
 
-	err := h.prService.CreatePR(pr)
+	err := h.prService.CreatePR(pr, service.CreatePROptions{
+		Repository:   req.Repository,
+		ChangedFiles: req.ChangedFiles,
+		Labels:       req.Labels,
+		Draft:        req.Draft,
+	})
 	if err != nil {
-		if err.Error() == "author not found" || err.Error() == "author has no team" {
-			writeError(w, http.StatusNotFound, "NOT_FOUND", "Author or team not found")
-		} else {
-			writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
-			slog.Error("Error creating PR", "error", err)
-		}
+		writeServiceError(w, err, "creating PR")
 		return
 	}
 
@@ -147,17 +445,80 @@ func (h *ServerHandler) PostPullRequestCreate(w http.ResponseWriter, r *http.Req
 }
 
 func (h *ServerHandler) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		PullRequestID string `json:"pull_request_id"`
+	var req api.PostPullRequestMergeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	pr, err := h.prService.MergePR(req.PullRequestId, valueOf(req.AdminOverride))
+	if err != nil {
+		writeServiceError(w, err, "merging PR")
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr": pr,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) PostPullRequestReadyForReview(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestReadyForReviewJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	opts := service.CreatePROptions{Repository: valueOf(req.Repository)}
+	if req.ChangedFiles != nil {
+		opts.ChangedFiles = *req.ChangedFiles
+	}
+	if req.Labels != nil {
+		opts.Labels = *req.Labels
+	}
+	pr, err := h.prService.ReadyForReview(req.PullRequestId, opts)
+	if err != nil {
+		writeServiceError(w, err, "marking PR ready for review")
+		return
 	}
+
+	response := map[string]interface{}{
+		"pr": pr,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) PostPullRequestReview(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestReviewJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
 		return
 	}
 
-	pr, err := h.prService.MergePR(req.PullRequestID)
+	pr, review, err := h.prService.SubmitReview(req.PullRequestId, req.UserId, req.Decision)
 	if err != nil {
-		writeError(w, http.StatusNotFound, "NOT_FOUND", "PR not found")
+		writeServiceError(w, err, "submitting review")
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr":     pr,
+		"review": review,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) PostPullRequestClose(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestCloseJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	pr, err := h.prService.ClosePR(req.PullRequestId)
+	if err != nil {
+		writeServiceError(w, err, "closing PR")
 		return
 	}
 
@@ -167,6 +528,103 @@ func (h *ServerHandler) PostPullRequestMerge(w http.ResponseWriter, r *http.Requ
 	writeJSON(w, http.StatusOK, response)
 }
 
+func (h *ServerHandler) PostPullRequestReopen(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestReopenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	pr, err := h.prService.ReopenPR(req.PullRequestId)
+	if err != nil {
+		writeServiceError(w, err, "reopening PR")
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr": pr,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) GetPullRequestHistory(w http.ResponseWriter, _ *http.Request, params api.GetPullRequestHistoryParams) {
+	history, err := h.prService.GetHistory(params.PullRequestId)
+	if err != nil {
+		writeServiceError(w, err, "getting PR history")
+		return
+	}
+	escalations, err := h.prService.GetEscalations(params.PullRequestId)
+	if err != nil {
+		writeServiceError(w, err, "getting PR escalations")
+		return
+	}
+
+	response := map[string]interface{}{
+		"pull_request_id": params.PullRequestId,
+		"history":         history,
+		"escalations":     escalations,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) GetPullRequestGet(w http.ResponseWriter, _ *http.Request, params api.GetPullRequestGetParams) {
+	pr, reviewers, err := h.prService.GetPRDetails(params.PullRequestId)
+	if err != nil {
+		writeServiceError(w, err, "getting PR")
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr":        pr,
+		"reviewers": reviewers,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) GetPullRequestList(w http.ResponseWriter, _ *http.Request, params api.GetPullRequestListParams) {
+	filter := repository.PRFilter{
+		AuthorID:    valueOf(params.AuthorId),
+		ReviewerID:  valueOf(params.ReviewerId),
+		TeamName:    valueOf(params.TeamName),
+		Status:      valueOf(params.Status),
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+		MergedFrom:  params.MergedFrom,
+		MergedTo:    params.MergedTo,
+		Name:        valueOf(params.Name),
+	}
+	var limit int
+	if params.Limit != nil {
+		limit = *params.Limit
+		if limit < 1 || limit > 100 {
+			writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "limit must be between 1 and 100")
+			return
+		}
+	}
+
+	prs, next, err := h.prService.ListPRs(filter, string(valueOf(params.SortBy)), string(valueOf(params.Order)), valueOf(params.Cursor), limit)
+	if err != nil {
+		writeServiceError(w, err, "listing PRs")
+		return
+	}
+
+	response := map[string]interface{}{
+		"pull_requests": prs,
+	}
+	if next != "" {
+		response["next_cursor"] = next
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func valueOf[T any](value *T) T {
+	var zero T
+	if value == nil {
+		return zero
+	}
+	return *value
+}
+
 func (h *ServerHandler) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		PullRequestID string `json:"pull_request_id"`
@@ -179,25 +637,13 @@ func (h *ServerHandler) PostPullRequestReassign(w http.ResponseWriter, r *http.R
 
 	pr, newReviewer, err := h.prService.ReassignReviewer(req.PullRequestID, req.OldUserID)
 	if err != nil {
-		errMsg := err.Error()
-		if errMsg == "PR not found" {
-			writeError(w, http.StatusNotFound, "NOT_FOUND", "PR not found")
-		} else if errMsg == "cannot reassign on merged PR" {
-			writeError(w, http.StatusConflict, "PR_MERGED", "cannot reassign on merged PR")
-		} else if errMsg == "reviewer is not assigned to this PR" {
-			writeError(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
-		} else if errMsg == "no active replacement candidate in team" {
-			writeError(w, http.StatusConflict, "NO_CANDIDATE", "no active replacement candidate in team")
-		} else {
-			writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
-			slog.Error("Error reassigning reviewer", "error", err)
-		}
+		writeServiceError(w, err, "reassigning reviewer")
 		return
 	}
 
 	response := map[string]interface{}{
 		"pr":          pr,
-		"replaced_by": *newReviewer,
+		"replaced_by": newReviewer,
 	}
 	writeJSON(w, http.StatusOK, response)
 }
@@ -211,8 +657,7 @@ func (h *ServerHandler) GetUsersGetReview(w http.ResponseWriter, _ *http.Request
 
 	prs, err := h.prService.FindPRsByReviewer(userID)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
-		slog.Error("Error getting PRs", "error", err)
+		writeServiceError(w, err, "getting PRs")
 		return
 	}
 
@@ -236,7 +681,7 @@ func (h *ServerHandler) GetUsersGetReview(w http.ResponseWriter, _ *http.Request
 func (h *ServerHandler) GetStats(w http.ResponseWriter, _ *http.Request) {
 	stats, err := h.prService.GetStatistics()
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		writeServiceError(w, err, "getting statistics")
 		return
 	}
 
@@ -257,14 +702,85 @@ func (h *ServerHandler) PostUsersDeactivateBatch(w http.ResponseWriter, r *http.
 
 	result, err := h.prService.DeactivateUsersAndReassignPRs(req.TeamName, req.UserIds)
 	if err != nil {
-		if err.Error() == "team not found" {
-			writeError(w, http.StatusNotFound, "NOT_FOUND", "Team not found")
-			return
-		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		writeServiceError(w, err, "deactivating users")
 		return
 	}
 
 	slog.Info("batch deactivation completed", "team", req.TeamName, "deactivated", result.DeactivatedCount, "reassigned", result.ReassignedCount)
 	writeJSON(w, http.StatusOK, result)
 }
+
+func (h *ServerHandler) PostOwnershipSet(w http.ResponseWriter, r *http.Request) {
+	var req api.PostOwnershipSetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	ruleSet, err := h.ownershipService.SetRules(req.Repository, req.Codeowners, req.Rules)
+	if err != nil {
+		writeServiceError(w, err, "saving ownership rules")
+		return
+	}
+
+	response := map[string]interface{}{
+		"ownership": ruleSet,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ServerHandler) GetOwnershipGet(w http.ResponseWriter, _ *http.Request, params api.GetOwnershipGetParams) {
+	if params.Repository == "" {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "repository parameter is required")
+		return
+	}
+
+	ruleSet, err := h.ownershipService.GetRules(params.Repository)
+	if err != nil {
+		writeServiceError(w, err, "getting ownership rules")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ruleSet)
+}
+
+func (h *ServerHandler) PostOwnershipDelete(w http.ResponseWriter, r *http.Request) {
+	var req api.PostOwnershipDeleteJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	if err := h.ownershipService.DeleteRules(req.Repository); err != nil {
+		writeServiceError(w, err, "deleting ownership rules")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ServerHandler) GetNotificationsDeliveries(w http.ResponseWriter, _ *http.Request, params api.GetNotificationsDeliveriesParams) {
+	var eventID string
+	if params.EventId != nil {
+		eventID = *params.EventId
+	}
+	var limit int
+	if params.Limit != nil {
+		limit = *params.Limit
+		if limit < 1 || limit > 1000 {
+			writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "limit must be between 1 and 1000")
+			return
+		}
+	}
+
+	deliveries, err := h.notifications.GetDeliveries(eventID, limit)
+	if err != nil {
+		writeServiceError(w, err, "getting notification deliveries")
+		return
+	}
+
+	response := map[string]interface{}{
+		"deliveries": deliveries,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/config"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/service"
+)
+
+const maxWebhookPayloadSize = 5 << 20
+
+type WebhookHandler struct {
+	config         config.WebhooksConfig
+	webhookService *service.WebhookService
+}
+
+func NewWebhookHandler(cfg config.WebhooksConfig, webhookService *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		config:         cfg,
+		webhookService: webhookService,
+	}
+}
+
+type githubPullRequestPayload struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Title  string `json:"title"`
+		Merged bool   `json:"merged"`
+		Draft  bool   `json:"draft"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+type gitlabMergeRequestPayload struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		Iid    int    `json:"iid"`
+		Title  string `json:"title"`
+		Action string `json:"action"`
+		Draft  bool   `json:"draft"`
+	} `json:"object_attributes"`
+}
+
+// PostWebhooksGithub accepts GitHub "pull_request" events signed with
+// X-Hub-Signature-256.
+func (h *WebhookHandler) PostWebhooksGithub(w http.ResponseWriter, r *http.Request) {
+	body, ok := readWebhookBody(w, r)
+	if !ok {
+		return
+	}
+	if !validGitHubSignature(h.config.GitHubSecret, body, r.Header.Get("X-Hub-Signature-256")) {
+		writeError(w, http.StatusUnauthorized, "INVALID_SIGNATURE", "Invalid webhook signature")
+		return
+	}
+	if r.Header.Get("X-GitHub-Event") != "pull_request" {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ignored"})
+		return
+	}
+
+	var payload githubPullRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	event := service.PullRequestEvent{
+		Source:          "github",
+		DeliveryID:      r.Header.Get("X-GitHub-Delivery"),
+		PullRequestID:   fmt.Sprintf("%s#%d", payload.Repository.FullName, payload.Number),
+		PullRequestName: payload.PullRequest.Title,
+		Repository:      payload.Repository.FullName,
+		AuthorLogin:     payload.PullRequest.User.Login,
+		Draft:           payload.PullRequest.Draft,
+	}
+	switch {
+	case payload.Action == "opened":
+		event.Action = service.PullRequestEventOpened
+	case payload.Action == "reopened":
+		event.Action = service.PullRequestEventReopened
+	case payload.Action == "ready_for_review":
+		event.Action = service.PullRequestEventReadyForReview
+	case payload.Action == "closed" && payload.PullRequest.Merged:
+		event.Action = service.PullRequestEventMerged
+	case payload.Action == "closed":
+		event.Action = service.PullRequestEventClosed
+	case payload.Action == "converted_to_draft":
+		// Reviewers are never taken back, so a PR does not return to draft.
+		slog.Info("Ignoring webhook", "source", event.Source, "action", payload.Action, "pull_request_id", event.PullRequestID)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ignored"})
+		return
+	default:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ignored"})
+		return
+	}
+
+	h.handlePullRequestEvent(w, event)
+}
+
+// PostWebhooksGitlab accepts GitLab "Merge Request Hook" events carrying the
+// configured secret in X-Gitlab-Token.
+func (h *WebhookHandler) PostWebhooksGitlab(w http.ResponseWriter, r *http.Request) {
+	body, ok := readWebhookBody(w, r)
+	if !ok {
+		return
+	}
+	if !validGitLabToken(h.config.GitLabSecret, r.Header.Get("X-Gitlab-Token")) {
+		writeError(w, http.StatusUnauthorized, "INVALID_SIGNATURE", "Invalid webhook token")
+		return
+	}
+	if r.Header.Get("X-Gitlab-Event") != "Merge Request Hook" {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ignored"})
+		return
+	}
+
+	var payload gitlabMergeRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil || payload.ObjectKind != "merge_request" {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	event := service.PullRequestEvent{
+		Source:          "gitlab",
+		DeliveryID:      r.Header.Get("X-Gitlab-Event-UUID"),
+		PullRequestID:   fmt.Sprintf("%s!%d", payload.Project.PathWithNamespace, payload.ObjectAttributes.Iid),
+		PullRequestName: payload.ObjectAttributes.Title,
+		Repository:      payload.Project.PathWithNamespace,
+		AuthorLogin:     payload.User.Username,
+		Draft:           payload.ObjectAttributes.Draft,
+	}
+	switch payload.ObjectAttributes.Action {
+	case "open":
+		event.Action = service.PullRequestEventOpened
+	case "reopen":
+		event.Action = service.PullRequestEventReopened
+	case "merge":
+		event.Action = service.PullRequestEventMerged
+	case "close":
+		event.Action = service.PullRequestEventClosed
+	default:
+		// Draft changes arrive as "update" and are not applied.
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ignored"})
+		return
+	}
+
+	h.handlePullRequestEvent(w, event)
+}
+
+func (h *WebhookHandler) handlePullRequestEvent(w http.ResponseWriter, event service.PullRequestEvent) {
+	pr, err := h.webhookService.HandlePullRequestEvent(event)
+	if errors.Is(err, domain.ErrDeliveryExists) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "duplicate"})
+		return
+	}
+	if err != nil {
+		writeServiceError(w, err, "handling "+event.Source+" webhook")
+		return
+	}
+
+	response := map[string]interface{}{
+		"status": "processed",
+		"pr":     pr,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func readWebhookBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayloadSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return nil, false
+	}
+	return body, true
+}
+
+// validGitHubSignature checks the "sha256=<hex>" HMAC of the raw body. An
+// empty secret rejects every request.
+func validGitHubSignature(secret string, body []byte, signature string) bool {
+	if secret == "" || !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+func validGitLabToken(secret string, token string) bool {
+	if secret == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(token)) == 1
+}
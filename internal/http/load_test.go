@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/config"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/http/handler"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository/inmemory"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/service"
@@ -76,9 +77,11 @@ func TestLoadBatchDeactivation(t *testing.T) {
 		}
 
 		teamService := service.NewTeamService(teamRepo)
-		userService := service.NewUserService(userRepo)
+		userService := service.NewUserService(userRepo, teamRepo)
 		prService := service.NewPullRequestService(prRepo, teamRepo, userRepo)
-		h := handler.NewServerHandler(teamService, userService, prService)
+		ownershipService := service.NewOwnershipService(inmemory.NewOwnershipRepository())
+		notifications := service.NewNotificationDispatcher(config.NotificationsConfig{}, inmemory.NewNotificationDeliveryRepository())
+		h := handler.NewServerHandler(teamService, userService, prService, ownershipService, notifications)
 
 		for i := 0; i < 10; i++ {
 			deactivateIDs := []string{
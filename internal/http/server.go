@@ -25,14 +25,24 @@ type Server struct {
 	Router  *chi.Mux
 	Logger  *slog.Logger
 	Handler *handler.ServerHandler
+	Webhook *handler.WebhookHandler
 }
 
-func New(config *config.Config, teamService *service.TeamService, userService *service.UserService, prService *service.PullRequestService) *Server {
+func New(
+	config *config.Config,
+	teamService *service.TeamService,
+	userService *service.UserService,
+	prService *service.PullRequestService,
+	ownershipService *service.OwnershipService,
+	webhookService *service.WebhookService,
+	notifications *service.NotificationDispatcher,
+) *Server {
 	return &Server{
 		Config:  config,
 		Router:  chi.NewRouter(),
 		Logger:  setupLogger(config.Server.Env),
-		Handler: handler.NewServerHandler(teamService, userService, prService),
+		Handler: handler.NewServerHandler(teamService, userService, prService, ownershipService, notifications),
+		Webhook: handler.NewWebhookHandler(config.Webhooks, webhookService),
 	}
 }
 
@@ -52,18 +62,46 @@ func (s *Server) configureRouter() {
 	s.Router.Use(middleware.DefaultLogger)
 
 	wrapper := &api.ServerInterfaceWrapper{
-		Handler: s.Handler,
+		Handler:          s.Handler,
+		ErrorHandlerFunc: s.Handler.HandleParamError,
 	}
 
 	s.Router.Post("/team/add", wrapper.PostTeamAdd)
 	s.Router.Get("/team/get", wrapper.GetTeamGet)
+	s.Router.Post("/team/update", wrapper.PostTeamUpdate)
+	s.Router.Post("/team/addMembers", wrapper.PostTeamAddMembers)
+	s.Router.Post("/team/removeMembers", wrapper.PostTeamRemoveMembers)
+	s.Router.Post("/team/rename", wrapper.PostTeamRename)
+	s.Router.Post("/team/archive", wrapper.PostTeamArchive)
+	s.Router.Post("/team/delete", wrapper.PostTeamDelete)
 	s.Router.Post("/users/setIsActive", wrapper.PostUsersSetIsActive)
 	s.Router.Get("/users/getReview", wrapper.GetUsersGetReview)
+	s.Router.Post("/users/create", wrapper.PostUsersCreate)
+	s.Router.Get("/users/get", wrapper.GetUsersGet)
+	s.Router.Get("/users/list", wrapper.GetUsersList)
+	s.Router.Post("/users/update", wrapper.PostUsersUpdate)
+	s.Router.Post("/users/offboard", wrapper.PostUsersOffboard)
+	s.Router.Post("/users/away/add", wrapper.PostUsersAwayAdd)
+	s.Router.Get("/users/away/list", wrapper.GetUsersAwayList)
+	s.Router.Post("/users/away/delete", wrapper.PostUsersAwayDelete)
 	s.Router.Post("/pullRequest/create", wrapper.PostPullRequestCreate)
 	s.Router.Post("/pullRequest/merge", wrapper.PostPullRequestMerge)
+	s.Router.Post("/pullRequest/readyForReview", wrapper.PostPullRequestReadyForReview)
+	s.Router.Post("/pullRequest/review", wrapper.PostPullRequestReview)
+	s.Router.Post("/pullRequest/close", wrapper.PostPullRequestClose)
+	s.Router.Post("/pullRequest/reopen", wrapper.PostPullRequestReopen)
 	s.Router.Post("/pullRequest/reassign", wrapper.PostPullRequestReassign)
+	s.Router.Get("/pullRequest/get", wrapper.GetPullRequestGet)
+	s.Router.Get("/pullRequest/history", wrapper.GetPullRequestHistory)
+	s.Router.Get("/pullRequest/list", wrapper.GetPullRequestList)
+	s.Router.Post("/ownership/set", wrapper.PostOwnershipSet)
+	s.Router.Get("/ownership/get", wrapper.GetOwnershipGet)
+	s.Router.Post("/ownership/delete", wrapper.PostOwnershipDelete)
+	s.Router.Get("/notifications/deliveries", wrapper.GetNotificationsDeliveries)
 	s.Router.Get("/stats", s.Handler.GetStats)
 	s.Router.Post("/users/deactivateBatch", s.Handler.PostUsersDeactivateBatch)
+	s.Router.Post("/webhooks/github", s.Webhook.PostWebhooksGithub)
+	s.Router.Post("/webhooks/gitlab", s.Webhook.PostWebhooksGitlab)
 }
 
 func setupLogger(env string) *slog.Logger {
@@ -21,15 +21,19 @@ func setupTestServer() *Server {
 		},
 	}
 
-	teamRepo := inmemory.NewTeamRepository()
 	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepositoryWithUsers(userRepo)
 	prRepo := inmemory.NewPullRequestRepository()
+	teamRepo.LinkPullRequests(prRepo)
 
 	teamService := service.NewTeamService(teamRepo)
-	userService := service.NewUserService(userRepo)
+	userService := service.NewUserService(userRepo, teamRepo)
 	prService := service.NewPullRequestService(prRepo, teamRepo, userRepo)
+	ownershipService := service.NewOwnershipService(inmemory.NewOwnershipRepository())
+	notifications := service.NewNotificationDispatcher(config.NotificationsConfig{}, inmemory.NewNotificationDeliveryRepository())
+	webhookService := service.NewWebhookService(prService, userRepo, inmemory.NewWebhookDeliveryRepository(), nil)
 
-	return New(cfg, teamService, userService, prService)
+	return New(cfg, teamService, userService, prService, ownershipService, webhookService, notifications)
 }
 
 func TestPostTeamAdd(t *testing.T) {
@@ -80,3 +84,130 @@ func TestGetTeamGet(t *testing.T) {
 		t.Errorf("Expected status 200 or 404, got %d", w.Code)
 	}
 }
+
+func TestGetPullRequestHistory(t *testing.T) {
+	server := setupTestServer()
+	server.configureRouter()
+
+	req := httptest.NewRequest("GET", "/pullRequest/history?pull_request_id=pr-404", nil)
+	w := httptest.NewRecorder()
+	server.Router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/pullRequest/history", nil)
+	w = httptest.NewRecorder()
+	server.Router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestGetPullRequestGet(t *testing.T) {
+	server := setupTestServer()
+	server.configureRouter()
+
+	post := func(path string, payload interface{}) {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest("POST", path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		server.Router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	post("/team/add", api.Team{TeamName: "backend", Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+	}})
+	post("/pullRequest/create", map[string]string{"pull_request_id": "pr-1", "pull_request_name": "Fix", "author_id": "u1"})
+
+	req := httptest.NewRequest("GET", "/pullRequest/get?pull_request_id=pr-1", nil)
+	w := httptest.NewRecorder()
+	server.Router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var response struct {
+		PR        api.PullRequest       `json:"pr"`
+		Reviewers []api.ReviewerDetails `json:"reviewers"`
+	}
+	_ = json.NewDecoder(w.Body).Decode(&response)
+	if response.PR.PullRequestId != "pr-1" || response.PR.Status != api.PullRequestStatusOPEN {
+		t.Errorf("Expected open pr-1, got %+v", response.PR)
+	}
+	if len(response.Reviewers) != 1 {
+		t.Fatalf("Expected one reviewer, got %+v", response.Reviewers)
+	}
+	reviewer := response.Reviewers[0]
+	if reviewer.UserId != "u2" || reviewer.Username != "Bob" || reviewer.TeamName != "backend" ||
+		!reviewer.IsActive || reviewer.Action != api.ReviewerAssignmentActionCreate || reviewer.AssignedAt.IsZero() {
+		t.Errorf("Expected u2 assigned on create, got %+v", reviewer)
+	}
+
+	req = httptest.NewRequest("GET", "/pullRequest/get?pull_request_id=pr-404", nil)
+	w = httptest.NewRecorder()
+	server.Router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestErrorResponsesCarryDomainCodes(t *testing.T) {
+	cfg := &config.Config{Server: config.ServerConfig{Env: "local", Port: ":8080"}}
+	teamRepo := inmemory.NewTeamRepository()
+	userRepo := inmemory.NewUserRepository()
+	prRepo := inmemory.NewPullRequestRepository()
+	prService := service.NewPullRequestService(prRepo, teamRepo, userRepo)
+	server := New(cfg, service.NewTeamService(teamRepo), service.NewUserService(userRepo, teamRepo), prService,
+		service.NewOwnershipService(inmemory.NewOwnershipRepository()),
+		service.NewWebhookService(prService, userRepo, inmemory.NewWebhookDeliveryRepository(), nil),
+		service.NewNotificationDispatcher(config.NotificationsConfig{}, inmemory.NewNotificationDeliveryRepository()))
+	server.configureRouter()
+
+	post := func(path string, payload interface{}) (int, api.ErrorResponse) {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest("POST", path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		server.Router.ServeHTTP(w, req)
+		var response api.ErrorResponse
+		_ = json.NewDecoder(w.Body).Decode(&response)
+		return w.Code, response
+	}
+
+	team := api.Team{TeamName: "backend", Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+	}}
+	post("/team/add", team)
+	for _, member := range team.Members {
+		userRepo.AddUser(&api.User{UserId: member.UserId, Username: member.Username, IsActive: true, TeamName: "backend"})
+	}
+	post("/pullRequest/create", map[string]string{"pull_request_id": "pr-1", "pull_request_name": "Test", "author_id": "u1"})
+	post("/pullRequest/merge", map[string]string{"pull_request_id": "pr-1"})
+
+	tests := []struct {
+		name    string
+		path    string
+		payload interface{}
+		status  int
+		code    api.ErrorResponseErrorCode
+	}{
+		{"team exists", "/team/add", team, http.StatusBadRequest, api.TEAMEXISTS},
+		{"PR exists", "/pullRequest/create", map[string]string{"pull_request_id": "pr-1", "author_id": "u1"}, http.StatusConflict, api.PREXISTS},
+		{"unknown author", "/pullRequest/create", map[string]string{"pull_request_id": "pr-2", "author_id": "u404"}, http.StatusNotFound, api.NOTFOUND},
+		{"unknown PR", "/pullRequest/merge", map[string]string{"pull_request_id": "pr-404"}, http.StatusNotFound, api.NOTFOUND},
+		{"merged PR", "/pullRequest/reassign", map[string]string{"pull_request_id": "pr-1", "old_user_id": "u2"}, http.StatusConflict, api.PRMERGED},
+		{"unknown user", "/users/setIsActive", map[string]interface{}{"user_id": "u404", "is_active": false}, http.StatusNotFound, api.NOTFOUND},
+		{"user exists", "/users/create", map[string]string{"user_id": "u1", "username": "Alice"}, http.StatusConflict, api.USEREXISTS},
+		{"unknown team", "/users/create", map[string]interface{}{"user_id": "u3", "username": "Carol", "teams": []string{"nope"}}, http.StatusNotFound, api.NOTFOUND},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, response := post(tt.path, tt.payload)
+			if status != tt.status || response.Error.Code != tt.code {
+				t.Errorf("Expected %d %s, got %d %s", tt.status, tt.code, status, response.Error.Code)
+			}
+		})
+	}
+}
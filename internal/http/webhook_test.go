@@ -0,0 +1,264 @@
+package http
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/config"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository/inmemory"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/service"
+)
+
+const (
+	testGitHubSecret = "github-secret"
+	testGitLabSecret = "gitlab-secret"
+)
+
+type webhookResponse struct {
+	Status string           `json:"status"`
+	PR     *api.PullRequest `json:"pr"`
+}
+
+func setupWebhookServer(t *testing.T) *Server {
+	t.Helper()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{Env: "local", Port: ":8080"},
+		Webhooks: config.WebhooksConfig{
+			GitHubSecret: testGitHubSecret,
+			GitLabSecret: testGitLabSecret,
+			Users: map[string]string{
+				"Alice-GH": "u1",
+				"alice.gl": "u1",
+			},
+		},
+	}
+
+	teamRepo := inmemory.NewTeamRepository()
+	userRepo := inmemory.NewUserRepository()
+	prRepo := inmemory.NewPullRequestRepository()
+
+	members := []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+	}
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "payments", Members: members})
+	for _, member := range members {
+		userRepo.AddUser(&api.User{UserId: member.UserId, Username: member.Username, IsActive: true, TeamName: "payments"})
+	}
+
+	teamService := service.NewTeamService(teamRepo)
+	userService := service.NewUserService(userRepo, teamRepo)
+	prService := service.NewPullRequestService(prRepo, teamRepo, userRepo)
+	ownershipService := service.NewOwnershipService(inmemory.NewOwnershipRepository())
+	notifications := service.NewNotificationDispatcher(config.NotificationsConfig{}, inmemory.NewNotificationDeliveryRepository())
+	webhookService := service.NewWebhookService(prService, userRepo, inmemory.NewWebhookDeliveryRepository(), cfg.Webhooks.Users)
+
+	server := New(cfg, teamService, userService, prService, ownershipService, webhookService, notifications)
+	server.configureRouter()
+	return server
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Failed to read fixture %s: %v", name, err)
+	}
+	return body
+}
+
+func signGitHub(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func sendGitHubWebhook(server *Server, event, delivery string, body []byte, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/webhooks/github", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", delivery)
+	req.Header.Set("X-Hub-Signature-256", signature)
+	w := httptest.NewRecorder()
+	server.Router.ServeHTTP(w, req)
+	return w
+}
+
+func sendGitLabWebhook(server *Server, delivery string, body []byte, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/webhooks/gitlab", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Event-UUID", delivery)
+	req.Header.Set("X-Gitlab-Token", token)
+	w := httptest.NewRecorder()
+	server.Router.ServeHTTP(w, req)
+	return w
+}
+
+func decodeWebhookResponse(t *testing.T, w *httptest.ResponseRecorder) webhookResponse {
+	t.Helper()
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response webhookResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return response
+}
+
+func TestGitHubWebhookOpenAndMerge(t *testing.T) {
+	server := setupWebhookServer(t)
+
+	opened := readFixture(t, "github_pull_request_opened.json")
+	response := decodeWebhookResponse(t, sendGitHubWebhook(server, "pull_request", "d1", opened, signGitHub(opened, testGitHubSecret)))
+	if response.Status != "processed" {
+		t.Fatalf("Expected processed, got %s", response.Status)
+	}
+	if response.PR.PullRequestId != "acme/payments#42" || response.PR.AuthorId != "u1" {
+		t.Errorf("Unexpected PR %+v", response.PR)
+	}
+	if len(response.PR.AssignedReviewers) != 2 {
+		t.Errorf("Expected 2 reviewers, got %v", response.PR.AssignedReviewers)
+	}
+
+	response = decodeWebhookResponse(t, sendGitHubWebhook(server, "pull_request", "d1", opened, signGitHub(opened, testGitHubSecret)))
+	if response.Status != "duplicate" {
+		t.Errorf("Expected duplicate, got %s", response.Status)
+	}
+
+	merged := readFixture(t, "github_pull_request_merged.json")
+	response = decodeWebhookResponse(t, sendGitHubWebhook(server, "pull_request", "d2", merged, signGitHub(merged, testGitHubSecret)))
+	if response.PR.Status != api.PullRequestStatusMERGED {
+		t.Errorf("Expected MERGED, got %s", response.PR.Status)
+	}
+}
+
+// githubPayload returns the opened fixture with the given action and draft
+// flag.
+func githubPayload(t *testing.T, action string, draft bool) []byte {
+	t.Helper()
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(readFixture(t, "github_pull_request_opened.json"), &payload); err != nil {
+		t.Fatalf("Failed to decode fixture: %v", err)
+	}
+	payload["action"] = action
+	payload["pull_request"].(map[string]interface{})["draft"] = draft
+	body, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Failed to encode payload: %v", err)
+	}
+	return body
+}
+
+func TestGitHubWebhookDraftCloseAndReopen(t *testing.T) {
+	server := setupWebhookServer(t)
+	send := func(delivery, action string, draft bool) webhookResponse {
+		body := githubPayload(t, action, draft)
+		return decodeWebhookResponse(t, sendGitHubWebhook(server, "pull_request", delivery, body, signGitHub(body, testGitHubSecret)))
+	}
+
+	response := send("d1", "opened", true)
+	if !response.PR.IsDraft || len(response.PR.AssignedReviewers) != 0 {
+		t.Fatalf("Expected a draft without reviewers, got %+v", response.PR)
+	}
+	if response = send("d2", "converted_to_draft", true); response.Status != "ignored" {
+		t.Errorf("Expected converted_to_draft to be ignored, got %s", response.Status)
+	}
+	response = send("d3", "ready_for_review", false)
+	if response.PR.IsDraft || len(response.PR.AssignedReviewers) != 2 {
+		t.Errorf("Expected a ready PR with 2 reviewers, got %+v", response.PR)
+	}
+	response = send("d4", "closed", false)
+	if response.PR.Status != api.PullRequestStatusCLOSED {
+		t.Errorf("Expected CLOSED, got %s", response.PR.Status)
+	}
+	response = send("d5", "reopened", false)
+	if response.PR.Status != api.PullRequestStatusOPEN {
+		t.Errorf("Expected OPEN, got %s", response.PR.Status)
+	}
+}
+
+func TestGitHubWebhookRejectsInvalidSignature(t *testing.T) {
+	server := setupWebhookServer(t)
+
+	opened := readFixture(t, "github_pull_request_opened.json")
+	w := sendGitHubWebhook(server, "pull_request", "d1", opened, signGitHub(opened, "wrong-secret"))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", w.Code)
+	}
+}
+
+func TestGitHubWebhookIgnoresOtherEvents(t *testing.T) {
+	server := setupWebhookServer(t)
+
+	body := []byte(`{"zen":"Keep it logically awesome."}`)
+	response := decodeWebhookResponse(t, sendGitHubWebhook(server, "ping", "d1", body, signGitHub(body, testGitHubSecret)))
+	if response.Status != "ignored" {
+		t.Errorf("Expected ignored, got %s", response.Status)
+	}
+}
+
+func TestGitLabWebhookOpenAndMerge(t *testing.T) {
+	server := setupWebhookServer(t)
+
+	opened := readFixture(t, "gitlab_merge_request_open.json")
+	response := decodeWebhookResponse(t, sendGitLabWebhook(server, "e1", opened, testGitLabSecret))
+	if response.PR.PullRequestId != "acme/payments!7" || response.PR.AuthorId != "u1" {
+		t.Errorf("Unexpected PR %+v", response.PR)
+	}
+
+	merged := readFixture(t, "gitlab_merge_request_merge.json")
+	response = decodeWebhookResponse(t, sendGitLabWebhook(server, "e2", merged, testGitLabSecret))
+	if response.PR.Status != api.PullRequestStatusMERGED {
+		t.Errorf("Expected MERGED, got %s", response.PR.Status)
+	}
+
+	response = decodeWebhookResponse(t, sendGitLabWebhook(server, "e2", merged, testGitLabSecret))
+	if response.Status != "duplicate" {
+		t.Errorf("Expected duplicate, got %s", response.Status)
+	}
+}
+
+func TestGitLabWebhookCloseAndReopen(t *testing.T) {
+	server := setupWebhookServer(t)
+
+	opened := readFixture(t, "gitlab_merge_request_open.json")
+	decodeWebhookResponse(t, sendGitLabWebhook(server, "e1", opened, testGitLabSecret))
+
+	closed := bytes.Replace(opened, []byte(`"action": "open"`), []byte(`"action": "close"`), 1)
+	response := decodeWebhookResponse(t, sendGitLabWebhook(server, "e2", closed, testGitLabSecret))
+	if response.PR.Status != api.PullRequestStatusCLOSED {
+		t.Errorf("Expected CLOSED, got %s", response.PR.Status)
+	}
+
+	reopened := bytes.Replace(opened, []byte(`"action": "open"`), []byte(`"action": "reopen"`), 1)
+	response = decodeWebhookResponse(t, sendGitLabWebhook(server, "e3", reopened, testGitLabSecret))
+	if response.PR.Status != api.PullRequestStatusOPEN {
+		t.Errorf("Expected OPEN, got %s", response.PR.Status)
+	}
+}
+
+func TestGitLabWebhookRejectsInvalidToken(t *testing.T) {
+	server := setupWebhookServer(t)
+
+	opened := readFixture(t, "gitlab_merge_request_open.json")
+	w := sendGitLabWebhook(server, "e1", opened, "wrong-secret")
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", w.Code)
+	}
+}
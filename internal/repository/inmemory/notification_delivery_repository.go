@@ -0,0 +1,54 @@
+package inmemory
+
+import (
+	"sync"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+)
+
+type NotificationDeliveryRepository struct {
+	mu         sync.RWMutex
+	deliveries []api.NotificationDelivery
+}
+
+func NewNotificationDeliveryRepository() *NotificationDeliveryRepository {
+	return &NotificationDeliveryRepository{}
+}
+
+func (r *NotificationDeliveryRepository) SaveDelivery(delivery api.NotificationDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deliveries = append(r.deliveries, delivery)
+	return nil
+}
+
+// FindDeliveries returns the newest deliveries first. An empty eventID
+// matches every event.
+func (r *NotificationDeliveryRepository) FindDeliveries(eventID string, limit int) ([]api.NotificationDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := []api.NotificationDelivery{}
+	for i := len(r.deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		if eventID == "" || r.deliveries[i].EventId == eventID {
+			deliveries = append(deliveries, r.deliveries[i])
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *NotificationDeliveryRepository) FindDeliveredSubscribers(eventID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var urls []string
+	seen := make(map[string]bool)
+	for _, delivery := range r.deliveries {
+		if delivery.EventId == eventID && delivery.Success && !seen[delivery.SubscriberUrl] {
+			seen[delivery.SubscriberUrl] = true
+			urls = append(urls, delivery.SubscriberUrl)
+		}
+	}
+	return urls, nil
+}
@@ -0,0 +1,51 @@
+package inmemory
+
+import (
+	"sync"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+)
+
+type OwnershipRepository struct {
+	mu    sync.RWMutex
+	rules map[string][]api.OwnershipRule
+}
+
+func NewOwnershipRepository() *OwnershipRepository {
+	return &OwnershipRepository{
+		rules: make(map[string][]api.OwnershipRule),
+	}
+}
+
+func (r *OwnershipRepository) SaveRules(ruleSet api.OwnershipRuleSet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rules := make([]api.OwnershipRule, len(ruleSet.Rules))
+	copy(rules, ruleSet.Rules)
+	r.rules[ruleSet.Repository] = rules
+	return nil
+}
+
+func (r *OwnershipRepository) FindRulesByRepository(repository string) ([]api.OwnershipRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules, ok := r.rules[repository]
+	if !ok {
+		return nil, domain.ErrOwnershipNotFound
+	}
+	return rules, nil
+}
+
+func (r *OwnershipRepository) DeleteRules(repository string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rules[repository]; !ok {
+		return domain.ErrOwnershipNotFound
+	}
+	delete(r.rules, repository)
+	return nil
+}
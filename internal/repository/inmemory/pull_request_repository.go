@@ -1,31 +1,49 @@
 package inmemory
 
 import (
-	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
 )
 
 type PullRequestRepository struct {
-	mu  sync.RWMutex
-	prs map[string]*api.PullRequest
+	mu          sync.RWMutex
+	prs         map[string]*api.PullRequest
+	outbox      []repository.OutboxEvent
+	assignments map[string][]api.ReviewerAssignment
+	reviews     map[string][]api.ReviewDecision
+	escalations map[string][]api.ReviewEscalation
 }
 
 func NewPullRequestRepository() *PullRequestRepository {
 	return &PullRequestRepository{
-		prs: make(map[string]*api.PullRequest),
+		prs:         make(map[string]*api.PullRequest),
+		assignments: make(map[string][]api.ReviewerAssignment),
+		reviews:     make(map[string][]api.ReviewDecision),
+		escalations: make(map[string][]api.ReviewEscalation),
 	}
 }
 
-func (r *PullRequestRepository) CreatePR(pr api.PullRequest) error {
+func (r *PullRequestRepository) CreatePR(pr api.PullRequest, records ...repository.Record) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	if _, exists := r.prs[pr.PullRequestId]; exists {
-		return fmt.Errorf("PR already exists")
+		return domain.ErrPRExists
 	}
-	r.prs[pr.PullRequestId] = &pr
+	pr.Version = 0
+	if pr.CreatedAt == nil {
+		now := repository.SplitRecords(records).At
+		pr.CreatedAt = &now
+	}
+	r.prs[pr.PullRequestId] = clonePR(pr)
+	r.writeRecords(pr, records)
 	return nil
 }
 
@@ -35,19 +53,145 @@ func (r *PullRequestRepository) FindPRByID(prID string) (*api.PullRequest, error
 
 	pr, ok := r.prs[prID]
 	if !ok {
-		return nil, fmt.Errorf("PR not found")
+		return nil, domain.ErrPRNotFound
 	}
-	return pr, nil
+	return clonePR(*pr), nil
 }
 
-func (r *PullRequestRepository) UpdatePR(pr api.PullRequest) error {
+func (r *PullRequestRepository) UpdatePR(pr api.PullRequest, records ...repository.Record) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, ok := r.prs[pr.PullRequestId]; !ok {
-		return fmt.Errorf("PR not found")
+	stored, ok := r.prs[pr.PullRequestId]
+	if !ok {
+		return domain.ErrPRNotFound
 	}
-	r.prs[pr.PullRequestId] = &pr
+	if stored.Version != pr.Version {
+		return domain.ErrVersionConflict
+	}
+	pr.Version++
+	r.prs[pr.PullRequestId] = clonePR(pr)
+	r.writeRecords(pr, records)
+	return nil
+}
+
+// clonePR copies the reviewer slices too, so callers can change the result
+// without touching the stored PR.
+func clonePR(pr api.PullRequest) *api.PullRequest {
+	pr.AssignedReviewers = append([]string{}, pr.AssignedReviewers...)
+	if pr.FallbackReviewers != nil {
+		fallback := append([]string{}, *pr.FallbackReviewers...)
+		pr.FallbackReviewers = &fallback
+	}
+	if pr.OverflowReviewers != nil {
+		overflow := append([]string{}, *pr.OverflowReviewers...)
+		pr.OverflowReviewers = &overflow
+	}
+	return &pr
+}
+
+// writeRecords mirrors the Postgres repository: reviewers no longer assigned
+// are closed in the history, reviewers added without a record are logged as
+// manual, review decisions and escalations are appended. The caller holds
+// the lock.
+func (r *PullRequestRepository) writeRecords(pr api.PullRequest, records []repository.Record) {
+	split := repository.SplitRecords(records)
+	now := split.At
+
+	assigned := make(map[string]bool, len(pr.AssignedReviewers))
+	for _, reviewer := range pr.AssignedReviewers {
+		assigned[reviewer] = true
+	}
+	history := r.assignments[pr.PullRequestId]
+	open := make(map[string]bool)
+	for i := range history {
+		if history[i].UnassignedAt != nil {
+			continue
+		}
+		if !assigned[history[i].UserId] {
+			history[i].UnassignedAt = &now
+			continue
+		}
+		open[history[i].UserId] = true
+	}
+
+	for _, assignment := range split.Assignments {
+		history = append(history, assignment)
+		open[assignment.UserId] = true
+	}
+	for _, reviewer := range pr.AssignedReviewers {
+		if !open[reviewer] {
+			history = append(history, api.ReviewerAssignment{
+				PullRequestId: pr.PullRequestId,
+				UserId:        reviewer,
+				Action:        api.ReviewerAssignmentActionManual,
+				AssignedAt:    now,
+			})
+		}
+	}
+	r.assignments[pr.PullRequestId] = history
+	r.reviews[pr.PullRequestId] = append(r.reviews[pr.PullRequestId], split.Reviews...)
+	r.escalations[pr.PullRequestId] = append(r.escalations[pr.PullRequestId], split.Escalations...)
+
+	r.outbox = append(r.outbox, split.Events...)
+}
+
+func (r *PullRequestRepository) FindAssignmentsByPR(prID string) ([]api.ReviewerAssignment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	assignments := make([]api.ReviewerAssignment, len(r.assignments[prID]))
+	copy(assignments, r.assignments[prID])
+	return assignments, nil
+}
+
+func (r *PullRequestRepository) FindReviewsByPR(prID string) ([]api.ReviewDecision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reviews := make([]api.ReviewDecision, len(r.reviews[prID]))
+	copy(reviews, r.reviews[prID])
+	return reviews, nil
+}
+
+func (r *PullRequestRepository) FindEscalationsByPR(prID string) ([]api.ReviewEscalation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	escalations := make([]api.ReviewEscalation, len(r.escalations[prID]))
+	copy(escalations, r.escalations[prID])
+	return escalations, nil
+}
+
+func (r *PullRequestRepository) FindPendingEvents(limit int) ([]repository.OutboxEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if limit > len(r.outbox) {
+		limit = len(r.outbox)
+	}
+	events := make([]repository.OutboxEvent, limit)
+	copy(events, r.outbox)
+	return events, nil
+}
+
+// MarkEventsPublished drops the published events, there is nothing to keep
+// them for in memory.
+func (r *PullRequestRepository) MarkEventsPublished(eventIDs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	published := make(map[string]bool, len(eventIDs))
+	for _, eventID := range eventIDs {
+		published[eventID] = true
+	}
+	pending := r.outbox[:0]
+	for _, event := range r.outbox {
+		if !published[event.ID] {
+			pending = append(pending, event)
+		}
+	}
+	r.outbox = pending
 	return nil
 }
 
@@ -59,7 +203,7 @@ func (r *PullRequestRepository) FindPRsByReviewer(userID string) ([]api.PullRequ
 	for _, pr := range r.prs {
 		for _, reviewer := range pr.AssignedReviewers {
 			if reviewer == userID {
-				result = append(result, *pr)
+				result = append(result, *clonePR(*pr))
 				break
 			}
 		}
@@ -67,13 +211,159 @@ func (r *PullRequestRepository) FindPRsByReviewer(userID string) ([]api.PullRequ
 	return result, nil
 }
 
+func (r *PullRequestRepository) ListPRs(filter repository.PRFilter) ([]api.PullRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []api.PullRequest{}
+	for _, pr := range r.prs {
+		if matchesPRFilter(pr, filter) {
+			result = append(result, *clonePR(*pr))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return comparePRs(&result[i], prCursor(&result[j]), filter) < 0
+	})
+
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+	return result, nil
+}
+
+func matchesPRFilter(pr *api.PullRequest, filter repository.PRFilter) bool {
+	if filter.AuthorID != "" && pr.AuthorId != filter.AuthorID {
+		return false
+	}
+	if filter.ReviewerID != "" && !slices.Contains(pr.AssignedReviewers, filter.ReviewerID) {
+		return false
+	}
+	if filter.TeamName != "" && pr.TeamName != filter.TeamName {
+		return false
+	}
+	if filter.Status != "" && pr.Status != filter.Status {
+		return false
+	}
+	if !inRange(pr.CreatedAt, filter.CreatedFrom, filter.CreatedTo) || !inRange(pr.MergedAt, filter.MergedFrom, filter.MergedTo) {
+		return false
+	}
+	if filter.Name != "" && !strings.Contains(strings.ToLower(pr.PullRequestName), strings.ToLower(filter.Name)) {
+		return false
+	}
+	if filter.QueuedOnly && !pr.IsQueued {
+		return false
+	}
+	return filter.After == nil || comparePRs(pr, *filter.After, filter) > 0
+}
+
+// inRange reports whether t is in [from, to); a missing t only matches when
+// there are no bounds.
+func inRange(t *time.Time, from *time.Time, to *time.Time) bool {
+	if from == nil && to == nil {
+		return true
+	}
+	if t == nil {
+		return false
+	}
+	return (from == nil || !t.Before(*from)) && (to == nil || t.Before(*to))
+}
+
+func prCursor(pr *api.PullRequest) repository.PRCursor {
+	cursor := repository.PRCursor{Name: pr.PullRequestName, PullRequestID: pr.PullRequestId}
+	if pr.CreatedAt != nil {
+		cursor.CreatedAt = *pr.CreatedAt
+	}
+	return cursor
+}
+
+// comparePRs orders pr against a cursor position the way ListPRs sorts.
+func comparePRs(pr *api.PullRequest, cursor repository.PRCursor, filter repository.PRFilter) int {
+	position := prCursor(pr)
+	var result int
+	if filter.SortBy == repository.PRSortName {
+		result = strings.Compare(position.Name, cursor.Name)
+	} else {
+		result = position.CreatedAt.Compare(cursor.CreatedAt)
+	}
+	if result == 0 {
+		result = strings.Compare(position.PullRequestID, cursor.PullRequestID)
+	}
+	if filter.Descending {
+		return -result
+	}
+	return result
+}
+
 func (r *PullRequestRepository) GetAllPRs() ([]api.PullRequest, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	var result []api.PullRequest
 	for _, pr := range r.prs {
-		result = append(result, *pr)
+		result = append(result, *clonePR(*pr))
 	}
 	return result, nil
 }
+
+func (r *PullRequestRepository) CountOpenReviewsByUsers(userIDs []string) (map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		wanted[userID] = true
+	}
+
+	counts := make(map[string]int, len(userIDs))
+	for _, pr := range r.prs {
+		if pr.Status != api.PullRequestStatusOPEN || pr.IsDraft {
+			continue
+		}
+		for _, reviewer := range pr.AssignedReviewers {
+			if wanted[reviewer] {
+				counts[reviewer]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// renameTeam moves the pull requests of teamName to newTeamName.
+func (r *PullRequestRepository) renameTeam(teamName string, newTeamName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, pr := range r.prs {
+		if pr.TeamName == teamName {
+			pr.TeamName = newTeamName
+		}
+	}
+}
+
+// CountRecentPairings counts, per user, the PRs of authorID the user was
+// assigned to at or after since.
+func (r *PullRequestRepository) CountRecentPairings(authorID string, userIDs []string, since time.Time) (map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		wanted[userID] = true
+	}
+
+	counts := make(map[string]int, len(userIDs))
+	for prID, history := range r.assignments {
+		pr, ok := r.prs[prID]
+		if !ok || pr.AuthorId != authorID {
+			continue
+		}
+		paired := make(map[string]bool)
+		for _, assignment := range history {
+			if wanted[assignment.UserId] && !paired[assignment.UserId] && !assignment.AssignedAt.Before(since) {
+				paired[assignment.UserId] = true
+				counts[assignment.UserId]++
+			}
+		}
+	}
+	return counts, nil
+}
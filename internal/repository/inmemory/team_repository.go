@@ -1,15 +1,18 @@
 package inmemory
 
 import (
-	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
 )
 
 type TeamRepository struct {
 	mu    sync.RWMutex
 	teams map[string]api.Team
+	users *UserRepository
+	prs   *PullRequestRepository
 }
 
 func NewTeamRepository() *TeamRepository {
@@ -18,25 +21,61 @@ func NewTeamRepository() *TeamRepository {
 	}
 }
 
+// NewTeamRepositoryWithUsers returns a repository that keeps membership in
+// the teams of users, the way team_memberships is shared by both in
+// Postgres. Members written through either repository are seen by both.
+func NewTeamRepositoryWithUsers(users *UserRepository) *TeamRepository {
+	r := NewTeamRepository()
+	r.users = users
+	return r
+}
+
+// LinkPullRequests makes RenameTeam move the pull requests of a team to its
+// new name, the way pull_requests.team_name is updated in Postgres.
+func (r *TeamRepository) LinkPullRequests(prs *PullRequestRepository) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.prs = prs
+}
+
 func (r *TeamRepository) CreateTeam(team api.Team) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	if _, exists := r.teams[team.TeamName]; exists {
-		return fmt.Errorf("team already exists")
+		return domain.ErrTeamExists
 	}
-	r.teams[team.TeamName] = team
+	r.store(team)
 	return nil
 }
 
+// UpdateTeam stores the settings of team. Members are not touched.
 func (r *TeamRepository) UpdateTeam(team api.Team) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	stored, exists := r.teams[team.TeamName]
+	if !exists {
+		return domain.ErrTeamNotFound
+	}
+	team.Members = stored.Members
+	team.IsArchived = stored.IsArchived
 	r.teams[team.TeamName] = team
 	return nil
 }
 
+// store saves team; with linked users its members are written to them.
+func (r *TeamRepository) store(team api.Team) {
+	if r.users != nil {
+		for _, member := range team.Members {
+			r.users.upsertMember(member, team.TeamName)
+		}
+		team.Members = nil
+	}
+	r.teams[team.TeamName] = team
+}
+
 func (r *TeamRepository) ExistTeamByName(name string) bool {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -49,13 +88,26 @@ func (r *TeamRepository) FindTeamByName(name string) api.Team {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	return r.teams[name]
+	team, ok := r.teams[name]
+	if !ok {
+		return api.Team{}
+	}
+	team.Members = r.members(name)
+	return team
 }
 
 func (r *TeamRepository) FindTeamsByUser(userID string) ([]string, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	if r.users != nil {
+		user, err := r.users.FindUserByID(userID)
+		if err != nil {
+			return nil, nil
+		}
+		return append([]string{}, user.Teams...), nil
+	}
+
 	var teamNames []string
 	for teamName, team := range r.teams {
 		for _, member := range team.Members {
@@ -65,6 +117,7 @@ func (r *TeamRepository) FindTeamsByUser(userID string) ([]string, error) {
 			}
 		}
 	}
+	sort.Strings(teamNames)
 	return teamNames, nil
 }
 
@@ -72,9 +125,164 @@ func (r *TeamRepository) FindTeamMembersByName(teamName string) ([]api.TeamMembe
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	if _, ok := r.teams[teamName]; !ok {
+		return nil, domain.ErrTeamNotFound
+	}
+	return r.members(teamName), nil
+}
+
+func (r *TeamRepository) AddMembers(teamName string, members []api.TeamMember) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	team, ok := r.teams[teamName]
+	if !ok {
+		return domain.ErrTeamNotFound
+	}
+	if r.users != nil {
+		r.store(api.Team{TeamName: teamName, Members: members})
+		return nil
+	}
+
+	userIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserId)
+	}
+	r.dropMembers(teamName, userIDs)
+	team = r.teams[teamName]
+	team.Members = append(append([]api.TeamMember{}, team.Members...), members...)
+	r.teams[teamName] = team
+	return nil
+}
+
+func (r *TeamRepository) RemoveMembers(teamName string, userIDs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.teams[teamName]; !ok {
+		return domain.ErrTeamNotFound
+	}
+	if r.users != nil {
+		for _, userID := range userIDs {
+			r.users.replaceUserTeam(userID, teamName, "")
+		}
+		return nil
+	}
+	r.dropMembers(teamName, userIDs)
+	return nil
+}
+
+func (r *TeamRepository) RenameTeam(teamName string, newTeamName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	team, ok := r.teams[teamName]
+	if !ok {
+		return domain.ErrTeamNotFound
+	}
+	if _, exists := r.teams[newTeamName]; exists {
+		return domain.ErrTeamExists
+	}
+
+	members := r.members(teamName)
+	delete(r.teams, teamName)
+	team.TeamName = newTeamName
+	r.teams[newTeamName] = team
+	r.replaceFallbackTeam(teamName, newTeamName)
+	r.moveUsers(members, teamName, newTeamName)
+	if r.prs != nil {
+		r.prs.renameTeam(teamName, newTeamName)
+	}
+	return nil
+}
+
+func (r *TeamRepository) ArchiveTeam(teamName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	team, ok := r.teams[teamName]
 	if !ok {
-		return nil, fmt.Errorf("team not found")
+		return domain.ErrTeamNotFound
+	}
+	team.IsArchived = true
+	r.teams[teamName] = team
+	return nil
+}
+
+func (r *TeamRepository) DeleteTeam(teamName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.teams[teamName]; !ok {
+		return domain.ErrTeamNotFound
+	}
+	members := r.members(teamName)
+	delete(r.teams, teamName)
+	r.replaceFallbackTeam(teamName, "")
+	r.moveUsers(members, teamName, "")
+	return nil
+}
+
+// members lists the members of a team, read from the linked users when
+// there are any.
+func (r *TeamRepository) members(teamName string) []api.TeamMember {
+	if r.users != nil {
+		return r.users.teamMembers(teamName)
+	}
+	return r.teams[teamName].Members
+}
+
+// dropMembers removes userIDs from a team stored without linked users.
+func (r *TeamRepository) dropMembers(teamName string, userIDs []string) {
+	drop := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		drop[userID] = true
+	}
+
+	team := r.teams[teamName]
+	var kept []api.TeamMember
+	for _, member := range team.Members {
+		if !drop[member.UserId] {
+			kept = append(kept, member)
+		}
+	}
+	team.Members = kept
+	r.teams[teamName] = team
+}
+
+// replaceFallbackTeam renames teamName in every fallback list, or removes it
+// when newTeamName is empty.
+func (r *TeamRepository) replaceFallbackTeam(teamName string, newTeamName string) {
+	for name, team := range r.teams {
+		if team.FallbackTeams == nil {
+			continue
+		}
+		var fallback []string
+		changed := false
+		for _, fallbackTeam := range *team.FallbackTeams {
+			if fallbackTeam != teamName {
+				fallback = append(fallback, fallbackTeam)
+				continue
+			}
+			changed = true
+			if newTeamName != "" {
+				fallback = append(fallback, newTeamName)
+			}
+		}
+		if changed {
+			team.FallbackTeams = &fallback
+			r.teams[name] = team
+		}
+	}
+}
+
+// moveUsers renames teamName in the teams of members, or removes it when
+// newTeamName is empty.
+func (r *TeamRepository) moveUsers(members []api.TeamMember, teamName string, newTeamName string) {
+	if r.users == nil {
+		return
+	}
+	for _, member := range members {
+		r.users.replaceUserTeam(member.UserId, teamName, newTeamName)
 	}
-	return team.Members, nil
 }
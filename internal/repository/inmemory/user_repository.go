@@ -1,15 +1,20 @@
 package inmemory
 
 import (
-	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
 )
 
 type UserRepository struct {
-	mu    sync.RWMutex
-	users map[string]*api.User
+	mu          sync.RWMutex
+	users       map[string]*api.User
+	awayPeriods []api.AwayPeriod
+	nextAwayID  int64
 }
 
 func NewUserRepository() *UserRepository {
@@ -24,30 +29,264 @@ func (r *UserRepository) FindUserByID(userID string) (*api.User, error) {
 
 	user, ok := r.users[userID]
 	if !ok {
-		return nil, fmt.Errorf("user not found")
+		return nil, domain.ErrUserNotFound
 	}
 	return user, nil
 }
 
+func (r *UserRepository) CreateUser(user api.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.users[user.UserId]; exists {
+		return domain.ErrUserExists
+	}
+	setTeams(&user, append([]string{}, user.Teams...))
+	r.users[user.UserId] = &user
+	return nil
+}
+
+func (r *UserRepository) UpdateUser(user api.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[user.UserId]
+	if !ok {
+		return domain.ErrUserNotFound
+	}
+	stored.Username = user.Username
+	stored.IsActive = user.IsActive
+	stored.MaxOpenReviews = user.MaxOpenReviews
+	stored.Tags = user.Tags
+	stored.Seniority = user.Seniority
+
+	// Kept teams stay in the order they were joined, new ones follow.
+	teams := []string{}
+	for _, team := range stored.Teams {
+		if memberOf(&user, team) {
+			teams = append(teams, team)
+		}
+	}
+	for _, team := range user.Teams {
+		if !memberOf(stored, team) {
+			teams = append(teams, team)
+		}
+	}
+	setTeams(stored, teams)
+	return nil
+}
+
+func (r *UserRepository) ListUsers(filter repository.UserFilter) ([]api.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []api.User{}
+	for _, user := range r.users {
+		if filter.TeamName != "" && !memberOf(user, filter.TeamName) {
+			continue
+		}
+		if filter.IsActive != nil && user.IsActive != *filter.IsActive {
+			continue
+		}
+		if filter.Tag != "" && !hasTag(user, filter.Tag) {
+			continue
+		}
+		result = append(result, *user)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].UserId < result[j].UserId
+	})
+	return result, nil
+}
+
+func (r *UserRepository) CreateAwayPeriod(period api.AwayPeriod) (*api.AwayPeriod, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[period.UserId]; !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	r.nextAwayID++
+	period.Id = r.nextAwayID
+	r.awayPeriods = append(r.awayPeriods, period)
+	return &period, nil
+}
+
+func (r *UserRepository) FindAwayPeriodsByUser(userID string) ([]api.AwayPeriod, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []api.AwayPeriod{}
+	for _, period := range r.awayPeriods {
+		if period.UserId == userID {
+			result = append(result, period)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartsAt.Before(result[j].StartsAt)
+	})
+	return result, nil
+}
+
+func (r *UserRepository) FindAwayPeriodsAt(at time.Time) ([]api.AwayPeriod, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []api.AwayPeriod{}
+	for _, period := range r.awayPeriods {
+		if !at.Before(period.StartsAt) && at.Before(period.EndsAt) {
+			result = append(result, period)
+		}
+	}
+	return result, nil
+}
+
+func (r *UserRepository) MarkAwayPeriodHandedOff(periodID int64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.awayPeriods {
+		if r.awayPeriods[i].Id == periodID {
+			r.awayPeriods[i].HandedOffAt = &at
+			return nil
+		}
+	}
+	return domain.ErrAwayPeriodNotFound
+}
+
+func (r *UserRepository) DeleteAwayPeriod(periodID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, period := range r.awayPeriods {
+		if period.Id == periodID {
+			r.awayPeriods = append(r.awayPeriods[:i], r.awayPeriods[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrAwayPeriodNotFound
+}
+
 func (r *UserRepository) UpdateUserStatus(userID string, status bool) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	user, ok := r.users[userID]
 	if !ok {
-		return fmt.Errorf("user not found")
+		return domain.ErrUserNotFound
 	}
 	user.IsActive = status
 	return nil
 }
 
+// AddUser stores user. A user given only a team_name is a member of that
+// team.
 func (r *UserRepository) AddUser(user *api.User) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if len(user.Teams) == 0 && user.TeamName != "" {
+		user.Teams = []string{user.TeamName}
+	}
 	r.users[user.UserId] = user
 }
 
+// upsertMember creates or updates a user from a team member and adds it to
+// teamName.
+func (r *UserRepository) upsertMember(member api.TeamMember, teamName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[member.UserId]
+	if !ok {
+		user = &api.User{UserId: member.UserId}
+		r.users[member.UserId] = user
+	}
+	user.Username = member.Username
+	user.IsActive = member.IsActive
+	if member.Seniority != nil {
+		user.Seniority = member.Seniority
+	}
+	if memberOf(user, teamName) {
+		return
+	}
+	setTeams(user, append(append([]string{}, user.Teams...), teamName))
+}
+
+// replaceUserTeam renames teamName in the teams of userID, or removes it
+// when newTeamName is empty.
+func (r *UserRepository) replaceUserTeam(userID string, teamName string, newTeamName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if user, ok := r.users[userID]; ok {
+		setTeams(user, replaceTeam(user.Teams, teamName, newTeamName))
+	}
+}
+
+func replaceTeam(teams []string, teamName string, newTeamName string) []string {
+	result := []string{}
+	for _, team := range teams {
+		if team != teamName {
+			result = append(result, team)
+		} else if newTeamName != "" {
+			result = append(result, newTeamName)
+		}
+	}
+	return result
+}
+
+func setTeams(user *api.User, teams []string) {
+	user.Teams = teams
+	user.TeamName = ""
+	if len(teams) > 0 {
+		user.TeamName = teams[0]
+	}
+}
+
+// teamMembers lists the members of teamName ordered by user_id.
+func (r *UserRepository) teamMembers(teamName string) []api.TeamMember {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var members []api.TeamMember
+	for _, user := range r.users {
+		if memberOf(user, teamName) {
+			members = append(members, api.TeamMember{
+				UserId:    user.UserId,
+				Username:  user.Username,
+				IsActive:  user.IsActive,
+				Seniority: user.Seniority,
+			})
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].UserId < members[j].UserId
+	})
+	return members
+}
+
+func hasTag(user *api.User, tag string) bool {
+	if user.Tags == nil {
+		return false
+	}
+	for _, userTag := range *user.Tags {
+		if userTag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func memberOf(user *api.User, teamName string) bool {
+	for _, team := range user.Teams {
+		if team == teamName {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *UserRepository) GetAllUsers() ([]api.User, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
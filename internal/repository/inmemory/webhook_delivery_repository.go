@@ -0,0 +1,40 @@
+package inmemory
+
+import (
+	"sync"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+)
+
+type WebhookDeliveryRepository struct {
+	mu         sync.Mutex
+	deliveries map[string]map[string]bool
+}
+
+func NewWebhookDeliveryRepository() *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		deliveries: make(map[string]map[string]bool),
+	}
+}
+
+func (r *WebhookDeliveryRepository) SaveDelivery(source string, deliveryID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.deliveries[source] == nil {
+		r.deliveries[source] = make(map[string]bool)
+	}
+	if r.deliveries[source][deliveryID] {
+		return domain.ErrDeliveryExists
+	}
+	r.deliveries[source][deliveryID] = true
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) DeleteDelivery(source string, deliveryID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.deliveries[source], deliveryID)
+	return nil
+}
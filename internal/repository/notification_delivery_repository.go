@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+
+// NotificationDeliveryRepository is the log of notification attempts.
+// FindDeliveredSubscribers lists the subscriber URLs that have accepted the
+// event, so that a relayed event is not sent to them again.
+type NotificationDeliveryRepository interface {
+	SaveDelivery(delivery api.NotificationDelivery) error
+	FindDeliveries(eventID string, limit int) ([]api.NotificationDelivery, error)
+	FindDeliveredSubscribers(eventID string) ([]string, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import "time"
+
+// OutboxEvent is an encoded event stored in the same transaction as the
+// change that caused it.
+type OutboxEvent struct {
+	ID        string
+	Type      string
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+type OutboxRepository interface {
+	FindPendingEvents(limit int) ([]OutboxEvent, error)
+	MarkEventsPublished(eventIDs []string) error
+}
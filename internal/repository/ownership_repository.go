@@ -0,0 +1,9 @@
+package repository
+
+import "github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+
+type OwnershipRepository interface {
+	SaveRules(ruleSet api.OwnershipRuleSet) error
+	FindRulesByRepository(repository string) ([]api.OwnershipRule, error)
+	DeleteRules(repository string) error
+}
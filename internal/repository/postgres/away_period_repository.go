@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+)
+
+const selectAwayPeriods = `
+	SELECT id, user_id, starts_at, ends_at, delegate_id, hand_off_reviews, handed_off_at
+	FROM away_periods
+`
+
+func (r *UserRepository) CreateAwayPeriod(period api.AwayPeriod) (*api.AwayPeriod, error) {
+	err := r.db.QueryRow(`
+		INSERT INTO away_periods (user_id, starts_at, ends_at, delegate_id, hand_off_reviews)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, period.UserId, period.StartsAt, period.EndsAt, period.DelegateId, period.HandOffReviews).Scan(&period.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create away period: %w", translateError(err, nil))
+	}
+	return &period, nil
+}
+
+func (r *UserRepository) FindAwayPeriodsByUser(userID string) ([]api.AwayPeriod, error) {
+	rows, err := r.db.Queryx(selectAwayPeriods+`
+		WHERE user_id = $1
+		ORDER BY starts_at, id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find away periods: %w", err)
+	}
+	return scanAwayPeriods(rows)
+}
+
+func (r *UserRepository) FindAwayPeriodsAt(at time.Time) ([]api.AwayPeriod, error) {
+	rows, err := r.db.Queryx(selectAwayPeriods+`
+		WHERE starts_at <= $1 AND ends_at > $1
+		ORDER BY id
+	`, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find away periods: %w", err)
+	}
+	return scanAwayPeriods(rows)
+}
+
+func scanAwayPeriods(rows *sqlx.Rows) ([]api.AwayPeriod, error) {
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	periods := []api.AwayPeriod{}
+	for rows.Next() {
+		var period api.AwayPeriod
+		err := rows.Scan(&period.Id, &period.UserId, &period.StartsAt, &period.EndsAt,
+			&period.DelegateId, &period.HandOffReviews, &period.HandedOffAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan away period: %w", err)
+		}
+		periods = append(periods, period)
+	}
+	return periods, rows.Err()
+}
+
+func (r *UserRepository) MarkAwayPeriodHandedOff(periodID int64, at time.Time) error {
+	result, err := r.db.Exec("UPDATE away_periods SET handed_off_at = $1 WHERE id = $2", at, periodID)
+	if err != nil {
+		return fmt.Errorf("failed to update away period: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrAwayPeriodNotFound
+	}
+	return nil
+}
+
+func (r *UserRepository) DeleteAwayPeriod(periodID int64) error {
+	result, err := r.db.Exec("DELETE FROM away_periods WHERE id = $1", periodID)
+	if err != nil {
+		return fmt.Errorf("failed to delete away period: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrAwayPeriodNotFound
+	}
+	return nil
+}
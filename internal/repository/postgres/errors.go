@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+)
+
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+// translateError turns driver errors the client can act on into domain
+// errors that keep err as their cause. notFound is returned for
+// sql.ErrNoRows; other errors are returned unchanged.
+func translateError(err error, notFound *domain.Error) error {
+	if errors.Is(err, sql.ErrNoRows) && notFound != nil {
+		return domain.Wrap(notFound, err)
+	}
+
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch pqErr.Code {
+	case uniqueViolation:
+		switch pqErr.Constraint {
+		case "teams_pkey":
+			return domain.Wrap(domain.ErrTeamExists, err)
+		case "pull_requests_pkey":
+			return domain.Wrap(domain.ErrPRExists, err)
+		case "users_pkey":
+			return domain.Wrap(domain.ErrUserExists, err)
+		}
+		return domain.Wrap(domain.ErrUniqueViolation, err)
+	case foreignKeyViolation:
+		return domain.Wrap(domain.ErrForeignViolation, err)
+	}
+	return err
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+)
+
+type NotificationDeliveryRepository struct {
+	db *sqlx.DB
+}
+
+func NewNotificationDeliveryRepository(db *sqlx.DB) *NotificationDeliveryRepository {
+	return &NotificationDeliveryRepository{
+		db: db,
+	}
+}
+
+func (r *NotificationDeliveryRepository) SaveDelivery(delivery api.NotificationDelivery) error {
+	_, err := r.db.Exec(`
+		INSERT INTO notification_deliveries
+			(event_id, event_type, subscriber_url, attempt, status_code, error, success, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, delivery.EventId, delivery.EventType, delivery.SubscriberUrl, delivery.Attempt,
+		delivery.StatusCode, delivery.Error, delivery.Success, delivery.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save notification delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *NotificationDeliveryRepository) FindDeliveries(eventID string, limit int) ([]api.NotificationDelivery, error) {
+	rows, err := r.db.Queryx(`
+		SELECT event_id, event_type, subscriber_url, attempt, status_code, error, success, created_at
+		FROM notification_deliveries
+		WHERE $1 = '' OR event_id = $1
+		ORDER BY id DESC
+		LIMIT $2
+	`, eventID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification deliveries: %w", err)
+	}
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	deliveries := []api.NotificationDelivery{}
+	for rows.Next() {
+		var delivery api.NotificationDelivery
+		err := rows.Scan(&delivery.EventId, &delivery.EventType, &delivery.SubscriberUrl, &delivery.Attempt,
+			&delivery.StatusCode, &delivery.Error, &delivery.Success, &delivery.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *NotificationDeliveryRepository) FindDeliveredSubscribers(eventID string) ([]string, error) {
+	var urls []string
+	err := r.db.Select(&urls, `
+		SELECT DISTINCT subscriber_url FROM notification_deliveries
+		WHERE event_id = $1 AND success
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find delivered subscribers: %w", err)
+	}
+	return urls, nil
+}
@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
+)
+
+// insertOutboxEvents stores events in the caller's transaction, so they are
+// committed or rolled back together with the change they describe.
+func insertOutboxEvents(tx *sqlx.Tx, events []repository.OutboxEvent) error {
+	for _, event := range events {
+		_, err := tx.Exec(`
+			INSERT INTO outbox_events (event_id, event_type, payload, created_at)
+			VALUES ($1, $2, $3, $4)
+		`, event.ID, event.Type, event.Payload, event.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to add outbox event: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *PullRequestRepository) FindPendingEvents(limit int) ([]repository.OutboxEvent, error) {
+	rows, err := r.db.Queryx(`
+		SELECT event_id, event_type, payload, created_at FROM outbox_events
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find outbox events: %w", err)
+	}
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var events []repository.OutboxEvent
+	for rows.Next() {
+		var event repository.OutboxEvent
+		if err := rows.Scan(&event.ID, &event.Type, &event.Payload, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (r *PullRequestRepository) MarkEventsPublished(eventIDs []string) error {
+	_, err := r.db.Exec(`
+		UPDATE outbox_events SET published_at = NOW()
+		WHERE event_id = ANY($1)
+	`, pq.Array(eventIDs))
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox events: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+)
+
+type OwnershipRepository struct {
+	db *sqlx.DB
+}
+
+func NewOwnershipRepository(db *sqlx.DB) *OwnershipRepository {
+	return &OwnershipRepository{
+		db: db,
+	}
+}
+
+func (r *OwnershipRepository) SaveRules(ruleSet api.OwnershipRuleSet) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	_, err = tx.Exec("DELETE FROM ownership_rules WHERE repository = $1", ruleSet.Repository)
+	if err != nil {
+		return fmt.Errorf("failed to delete old ownership rules: %w", err)
+	}
+
+	for position, rule := range ruleSet.Rules {
+		_, err = tx.Exec(`
+			INSERT INTO ownership_rules (repository, position, pattern, owners)
+			VALUES ($1, $2, $3, $4)
+		`, ruleSet.Repository, position, rule.Pattern, pq.Array(rule.Owners))
+		if err != nil {
+			return fmt.Errorf("failed to add ownership rule: %w", translateError(err, nil))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *OwnershipRepository) FindRulesByRepository(repository string) ([]api.OwnershipRule, error) {
+	rows, err := r.db.Queryx(`
+		SELECT pattern, owners FROM ownership_rules
+		WHERE repository = $1
+		ORDER BY position
+	`, repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find ownership rules: %w", err)
+	}
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var rules []api.OwnershipRule
+	for rows.Next() {
+		var rule api.OwnershipRule
+		if err := rows.Scan(&rule.Pattern, pq.Array(&rule.Owners)); err != nil {
+			return nil, fmt.Errorf("failed to scan ownership rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if rules == nil {
+		return nil, domain.ErrOwnershipNotFound
+	}
+	return rules, nil
+}
+
+func (r *OwnershipRepository) DeleteRules(repository string) error {
+	result, err := r.db.Exec("DELETE FROM ownership_rules WHERE repository = $1", repository)
+	if err != nil {
+		return fmt.Errorf("failed to delete ownership rules: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrOwnershipNotFound
+	}
+	return nil
+}
@@ -2,10 +2,14 @@ package postgres
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
 )
 
 type PullRequestRepository struct {
@@ -18,7 +22,7 @@ func NewPullRequestRepository(db *sqlx.DB) *PullRequestRepository {
 	}
 }
 
-func (r *PullRequestRepository) CreatePR(pr api.PullRequest) error {
+func (r *PullRequestRepository) CreatePR(pr api.PullRequest, records ...repository.Record) error {
 	tx, err := r.db.Beginx()
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -27,31 +31,88 @@ func (r *PullRequestRepository) CreatePR(pr api.PullRequest) error {
 		_ = tx.Rollback()
 	}(tx)
 
-	var createdAt interface{} = time.Now()
+	var createdAt interface{} = repository.SplitRecords(records).At
 	if pr.CreatedAt != nil {
 		createdAt = pr.CreatedAt
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at)
-		VALUES ($1, $2, $3, $4, $5)
-	`, pr.PullRequestId, pr.PullRequestName, pr.AuthorId, pr.Status, createdAt)
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, team_name, is_draft, is_queued)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8)
+	`, pr.PullRequestId, pr.PullRequestName, pr.AuthorId, pr.Status, createdAt, pr.TeamName, pr.IsDraft, pr.IsQueued)
 	if err != nil {
-		return fmt.Errorf("failed to create PR: %w", err)
+		return fmt.Errorf("failed to create PR: %w", translateError(err, nil))
+	}
+
+	if err := insertReviewers(tx, pr); err != nil {
+		return err
+	}
+
+	if err := writeRecords(tx, pr, records); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func insertReviewers(tx *sqlx.Tx, pr api.PullRequest) error {
+	fallback := make(map[string]bool)
+	if pr.FallbackReviewers != nil {
+		for _, reviewerID := range *pr.FallbackReviewers {
+			fallback[reviewerID] = true
+		}
+	}
+	overflow := make(map[string]bool)
+	if pr.OverflowReviewers != nil {
+		for _, reviewerID := range *pr.OverflowReviewers {
+			overflow[reviewerID] = true
+		}
 	}
 
 	for _, reviewerID := range pr.AssignedReviewers {
-		_, err = tx.Exec(`
-			INSERT INTO pr_reviewers (pull_request_id, user_id)
-			VALUES ($1, $2)
-		`, pr.PullRequestId, reviewerID)
+		_, err := tx.Exec(`
+			INSERT INTO pr_reviewers (pull_request_id, user_id, from_fallback, over_capacity)
+			VALUES ($1, $2, $3, $4)
+		`, pr.PullRequestId, reviewerID, fallback[reviewerID], overflow[reviewerID])
 		if err != nil {
-			return fmt.Errorf("failed to add reviewer: %w", err)
+			return fmt.Errorf("failed to add reviewer: %w", translateError(err, nil))
 		}
 	}
+	return nil
+}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+func (r *PullRequestRepository) loadReviewers(pr *api.PullRequest) error {
+	var rows []struct {
+		UserID       string `db:"user_id"`
+		FromFallback bool   `db:"from_fallback"`
+		OverCapacity bool   `db:"over_capacity"`
+	}
+	err := r.db.Select(&rows, `
+		SELECT user_id, from_fallback, over_capacity FROM pr_reviewers WHERE pull_request_id = $1
+	`, pr.PullRequestId)
+	if err != nil {
+		return fmt.Errorf("failed to get reviewers: %w", err)
+	}
+
+	pr.AssignedReviewers = []string{}
+	var fallback, overflow []string
+	for _, row := range rows {
+		pr.AssignedReviewers = append(pr.AssignedReviewers, row.UserID)
+		if row.FromFallback {
+			fallback = append(fallback, row.UserID)
+		}
+		if row.OverCapacity {
+			overflow = append(overflow, row.UserID)
+		}
+	}
+	if len(fallback) > 0 {
+		pr.FallbackReviewers = &fallback
+	}
+	if len(overflow) > 0 {
+		pr.OverflowReviewers = &overflow
 	}
 	return nil
 }
@@ -62,29 +123,24 @@ func (r *PullRequestRepository) FindPRByID(prID string) (*api.PullRequest, error
 	var mergedAt *time.Time
 
 	err := r.db.QueryRow(`
-		SELECT pull_request_id as "pull_request_id", pull_request_name as "pull_request_name", author_id as "author_id", status, created_at, merged_at
+		SELECT pull_request_id as "pull_request_id", pull_request_name as "pull_request_name", author_id as "author_id", status, created_at, merged_at, version, COALESCE(team_name, ''), closed_at, is_draft, is_queued
 		FROM pull_requests WHERE pull_request_id = $1
-	`, prID).Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status, &createdAt, &mergedAt)
+	`, prID).Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status, &createdAt, &mergedAt, &pr.Version, &pr.TeamName, &pr.ClosedAt, &pr.IsDraft, &pr.IsQueued)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find PR: %w", err)
+		return nil, fmt.Errorf("failed to find PR: %w", translateError(err, domain.ErrPRNotFound))
 	}
 
 	pr.CreatedAt = &createdAt
 	pr.MergedAt = mergedAt
 
-	var reviewers []string
-	err = r.db.Select(&reviewers, `
-		SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1
-	`, prID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get reviewers: %w", err)
+	if err := r.loadReviewers(&pr); err != nil {
+		return nil, err
 	}
-	pr.AssignedReviewers = reviewers
 
 	return &pr, nil
 }
 
-func (r *PullRequestRepository) UpdatePR(pr api.PullRequest) error {
+func (r *PullRequestRepository) UpdatePR(pr api.PullRequest, records ...repository.Record) error {
 	tx, err := r.db.Beginx()
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -97,29 +153,46 @@ func (r *PullRequestRepository) UpdatePR(pr api.PullRequest) error {
 	if pr.MergedAt != nil {
 		mergedAtValue = pr.MergedAt
 	}
+	var closedAtValue interface{}
+	if pr.ClosedAt != nil {
+		closedAtValue = pr.ClosedAt
+	}
 
-	_, err = tx.Exec(`
+	result, err := tx.Exec(`
 		UPDATE pull_requests 
-		SET status = $1, merged_at = $2
-		WHERE pull_request_id = $3
-	`, pr.Status, mergedAtValue, pr.PullRequestId)
+		SET status = $1, merged_at = $2, closed_at = $5, is_draft = $6, is_queued = $7, version = version + 1
+		WHERE pull_request_id = $3 AND version = $4
+	`, pr.Status, mergedAtValue, pr.PullRequestId, pr.Version, closedAtValue, pr.IsDraft, pr.IsQueued)
 	if err != nil {
 		return fmt.Errorf("failed to update PR: %w", err)
 	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		var exists bool
+		err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = $1)", pr.PullRequestId).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("failed to check PR: %w", err)
+		}
+		if !exists {
+			return domain.ErrPRNotFound
+		}
+		return domain.ErrVersionConflict
+	}
 
 	_, err = tx.Exec("DELETE FROM pr_reviewers WHERE pull_request_id = $1", pr.PullRequestId)
 	if err != nil {
 		return fmt.Errorf("failed to delete old reviewers: %w", err)
 	}
 
-	for _, reviewerID := range pr.AssignedReviewers {
-		_, err = tx.Exec(`
-			INSERT INTO pr_reviewers (pull_request_id, user_id)
-			VALUES ($1, $2)
-		`, pr.PullRequestId, reviewerID)
-		if err != nil {
-			return fmt.Errorf("failed to add reviewer: %w", err)
-		}
+	if err := insertReviewers(tx, pr); err != nil {
+		return err
+	}
+
+	if err := writeRecords(tx, pr, records); err != nil {
+		return err
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -129,10 +202,8 @@ func (r *PullRequestRepository) UpdatePR(pr api.PullRequest) error {
 }
 
 func (r *PullRequestRepository) FindPRsByReviewer(userID string) ([]api.PullRequest, error) {
-	var prs []api.PullRequest
-
 	rows, err := r.db.Queryx(`
-		SELECT pr.pull_request_id as "pull_request_id", pr.pull_request_name as "pull_request_name", pr.author_id as "author_id", pr.status, pr.created_at, pr.merged_at
+		SELECT pr.pull_request_id as "pull_request_id", pr.pull_request_name as "pull_request_name", pr.author_id as "author_id", pr.status, pr.created_at, pr.merged_at, pr.version, COALESCE(pr.team_name, ''), pr.closed_at, pr.is_draft, pr.is_queued
 		FROM pull_requests pr
 		WHERE pr.pull_request_id IN (
 			SELECT pull_request_id FROM pr_reviewers WHERE user_id = $1
@@ -142,16 +213,23 @@ func (r *PullRequestRepository) FindPRsByReviewer(userID string) ([]api.PullRequ
 	if err != nil {
 		return nil, fmt.Errorf("failed to find PRs: %w", err)
 	}
+	return r.scanPRs(rows)
+}
+
+// scanPRs reads pull requests selected with the columns of selectPRs and
+// loads their reviewers.
+func (r *PullRequestRepository) scanPRs(rows *sqlx.Rows) ([]api.PullRequest, error) {
 	defer func(rows *sqlx.Rows) {
 		_ = rows.Close()
 	}(rows)
 
+	var prs []api.PullRequest
 	for rows.Next() {
 		var pr api.PullRequest
 		var createdAt time.Time
 		var mergedAt *time.Time
 
-		err := rows.Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status, &createdAt, &mergedAt)
+		err := rows.Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status, &createdAt, &mergedAt, &pr.Version, &pr.TeamName, &pr.ClosedAt, &pr.IsDraft, &pr.IsQueued)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan PR: %w", err)
 		}
@@ -159,14 +237,9 @@ func (r *PullRequestRepository) FindPRsByReviewer(userID string) ([]api.PullRequ
 		pr.CreatedAt = &createdAt
 		pr.MergedAt = mergedAt
 
-		var reviewers []string
-		err = r.db.Select(&reviewers, `
-			SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1
-		`, pr.PullRequestId)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get reviewers: %w", err)
+		if err := r.loadReviewers(&pr); err != nil {
+			return nil, err
 		}
-		pr.AssignedReviewers = reviewers
 
 		prs = append(prs, pr)
 	}
@@ -174,45 +247,167 @@ func (r *PullRequestRepository) FindPRsByReviewer(userID string) ([]api.PullRequ
 	return prs, rows.Err()
 }
 
-func (r *PullRequestRepository) GetAllPRs() ([]api.PullRequest, error) {
-	var prs []api.PullRequest
+const selectPRs = `
+	SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at, pr.version, COALESCE(pr.team_name, ''), pr.closed_at, pr.is_draft, pr.is_queued
+	FROM pull_requests pr
+`
+
+// ListPRs builds the filter into the query and pages with a keyset on the
+// sort column and pull_request_id. Names are compared with the "C"
+// collation to match the byte order used by the in-memory repository.
+func (r *PullRequestRepository) ListPRs(filter repository.PRFilter) ([]api.PullRequest, error) {
+	var conditions []string
+	var args []interface{}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.AuthorID != "" {
+		conditions = append(conditions, "pr.author_id = "+arg(filter.AuthorID))
+	}
+	if filter.ReviewerID != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM pr_reviewers rv WHERE rv.pull_request_id = pr.pull_request_id AND rv.user_id = "+arg(filter.ReviewerID)+")")
+	}
+	if filter.TeamName != "" {
+		conditions = append(conditions, "pr.team_name = "+arg(filter.TeamName))
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "pr.status = "+arg(filter.Status))
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "pr.created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "pr.created_at < "+arg(*filter.CreatedTo))
+	}
+	if filter.MergedFrom != nil {
+		conditions = append(conditions, "pr.merged_at >= "+arg(*filter.MergedFrom))
+	}
+	if filter.MergedTo != nil {
+		conditions = append(conditions, "pr.merged_at < "+arg(*filter.MergedTo))
+	}
+	if filter.Name != "" {
+		conditions = append(conditions, "strpos(lower(pr.pull_request_name), lower("+arg(filter.Name)+")) > 0")
+	}
+	if filter.QueuedOnly {
+		conditions = append(conditions, "pr.is_queued")
+	}
 
+	sortColumn := "pr.created_at"
+	if filter.SortBy == repository.PRSortName {
+		sortColumn = `pr.pull_request_name COLLATE "C"`
+	}
+	direction, comparison := "ASC", ">"
+	if filter.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	if filter.After != nil {
+		var position interface{} = filter.After.CreatedAt
+		if filter.SortBy == repository.PRSortName {
+			position = filter.After.Name
+		}
+		conditions = append(conditions, fmt.Sprintf(`(%s, pr.pull_request_id COLLATE "C") %s (%s, %s)`,
+			sortColumn, comparison, arg(position), arg(filter.After.PullRequestID)))
+	}
+
+	query := selectPRs
+	if len(conditions) > 0 {
+		query += "WHERE " + strings.Join(conditions, " AND ") + "\n"
+	}
+	query += fmt.Sprintf(`ORDER BY %s %s, pr.pull_request_id COLLATE "C" %s`, sortColumn, direction, direction)
+	if filter.Limit > 0 {
+		query += " LIMIT " + arg(filter.Limit)
+	}
+
+	rows, err := r.db.Queryx(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PRs: %w", err)
+	}
+	prs, err := r.scanPRs(rows)
+	if err != nil {
+		return nil, err
+	}
+	if prs == nil {
+		prs = []api.PullRequest{}
+	}
+	return prs, nil
+}
+
+func (r *PullRequestRepository) GetAllPRs() ([]api.PullRequest, error) {
 	rows, err := r.db.Queryx(`
-		SELECT pull_request_id as "pull_request_id", pull_request_name as "pull_request_name", author_id as "author_id", status, created_at, merged_at
+		SELECT pull_request_id as "pull_request_id", pull_request_name as "pull_request_name", author_id as "author_id", status, created_at, merged_at, version, COALESCE(team_name, ''), closed_at, is_draft, is_queued
 		FROM pull_requests
 		ORDER BY created_at DESC
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find PRs: %w", err)
 	}
+	return r.scanPRs(rows)
+}
+
+func (r *PullRequestRepository) CountOpenReviewsByUsers(userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	rows, err := r.db.Queryx(`
+		SELECT rv.user_id, COUNT(*)
+		FROM pr_reviewers rv
+		JOIN pull_requests pr ON pr.pull_request_id = rv.pull_request_id
+		WHERE pr.status = 'OPEN' AND NOT pr.is_draft AND rv.user_id = ANY($1)
+		GROUP BY rv.user_id
+	`, pq.Array(userIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count open reviews: %w", err)
+	}
 	defer func(rows *sqlx.Rows) {
 		_ = rows.Close()
 	}(rows)
 
 	for rows.Next() {
-		var pr api.PullRequest
-		var createdAt time.Time
-		var mergedAt *time.Time
-
-		err := rows.Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status, &createdAt, &mergedAt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan PR: %w", err)
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan review count: %w", err)
 		}
+		counts[userID] = count
+	}
 
-		pr.CreatedAt = &createdAt
-		pr.MergedAt = mergedAt
+	return counts, rows.Err()
+}
 
-		var reviewers []string
-		err = r.db.Select(&reviewers, `
-			SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1
-		`, pr.PullRequestId)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get reviewers: %w", err)
-		}
-		pr.AssignedReviewers = reviewers
+// CountRecentPairings counts, per user, the PRs of authorID the user was
+// assigned to at or after since.
+func (r *PullRequestRepository) CountRecentPairings(authorID string, userIDs []string, since time.Time) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
 
-		prs = append(prs, pr)
+	rows, err := r.db.Queryx(`
+		SELECT ra.user_id, COUNT(DISTINCT ra.pull_request_id)
+		FROM reviewer_assignments ra
+		JOIN pull_requests pr ON pr.pull_request_id = ra.pull_request_id
+		WHERE pr.author_id = $1 AND ra.user_id = ANY($2) AND ra.assigned_at >= $3
+		GROUP BY ra.user_id
+	`, authorID, pq.Array(userIDs), since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recent pairings: %w", err)
 	}
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
 
-	return prs, rows.Err()
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan pairing count: %w", err)
+		}
+		counts[userID] = count
+	}
+
+	return counts, rows.Err()
 }
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+)
+
+func insertReviews(tx *sqlx.Tx, reviews []api.ReviewDecision) error {
+	for _, review := range reviews {
+		_, err := tx.Exec(`
+			INSERT INTO review_decisions (pull_request_id, user_id, decision, submitted_at)
+			VALUES ($1, $2, $3, $4)
+		`, review.PullRequestId, review.UserId, review.Decision, review.SubmittedAt)
+		if err != nil {
+			return fmt.Errorf("failed to add review decision: %w", translateError(err, nil))
+		}
+	}
+	return nil
+}
+
+func (r *PullRequestRepository) FindReviewsByPR(prID string) ([]api.ReviewDecision, error) {
+	rows, err := r.db.Queryx(`
+		SELECT pull_request_id, user_id, decision, submitted_at
+		FROM review_decisions
+		WHERE pull_request_id = $1
+		ORDER BY submitted_at, id
+	`, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find review decisions: %w", err)
+	}
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	reviews := []api.ReviewDecision{}
+	for rows.Next() {
+		var review api.ReviewDecision
+		err := rows.Scan(&review.PullRequestId, &review.UserId, &review.Decision, &review.SubmittedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review decision: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, rows.Err()
+}
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+)
+
+func insertEscalations(tx *sqlx.Tx, escalations []api.ReviewEscalation) error {
+	for _, escalation := range escalations {
+		_, err := tx.Exec(`
+			INSERT INTO review_escalations (pull_request_id, user_id, stage, new_user_id, escalated_at)
+			VALUES ($1, $2, $3, $4, $5)
+		`, escalation.PullRequestId, escalation.UserId, escalation.Stage, escalation.NewUserId, escalation.EscalatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to add review escalation: %w", translateError(err, nil))
+		}
+	}
+	return nil
+}
+
+func (r *PullRequestRepository) FindEscalationsByPR(prID string) ([]api.ReviewEscalation, error) {
+	rows, err := r.db.Queryx(`
+		SELECT pull_request_id, user_id, stage, new_user_id, escalated_at
+		FROM review_escalations
+		WHERE pull_request_id = $1
+		ORDER BY escalated_at, id
+	`, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find review escalations: %w", err)
+	}
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	escalations := []api.ReviewEscalation{}
+	for rows.Next() {
+		var escalation api.ReviewEscalation
+		err := rows.Scan(&escalation.PullRequestId, &escalation.UserId, &escalation.Stage, &escalation.NewUserId, &escalation.EscalatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review escalation: %w", err)
+		}
+		escalations = append(escalations, escalation)
+	}
+	return escalations, rows.Err()
+}
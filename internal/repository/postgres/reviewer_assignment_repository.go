@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
+)
+
+// writeRecords stores the records passed with pr and brings the assignment
+// history in line with pr.AssignedReviewers: reviewers no longer assigned
+// are closed, reviewers added without a record are logged as manual.
+func writeRecords(tx *sqlx.Tx, pr api.PullRequest, records []repository.Record) error {
+	split := repository.SplitRecords(records)
+
+	_, err := tx.Exec(`
+		UPDATE reviewer_assignments SET unassigned_at = $3
+		WHERE pull_request_id = $1 AND unassigned_at IS NULL AND NOT (user_id = ANY($2))
+	`, pr.PullRequestId, pq.Array(pr.AssignedReviewers), split.At)
+	if err != nil {
+		return fmt.Errorf("failed to close reviewer assignments: %w", err)
+	}
+
+	for _, assignment := range split.Assignments {
+		_, err := tx.Exec(`
+			INSERT INTO reviewer_assignments (pull_request_id, user_id, action, replaced_user_id, assigned_at)
+			VALUES ($1, $2, $3, $4, $5)
+		`, assignment.PullRequestId, assignment.UserId, assignment.Action, assignment.ReplacedUserId, assignment.AssignedAt)
+		if err != nil {
+			return fmt.Errorf("failed to add reviewer assignment: %w", translateError(err, nil))
+		}
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO reviewer_assignments (pull_request_id, user_id, action, assigned_at)
+		SELECT $1, reviewer, $3, $4 FROM unnest($2::TEXT[]) AS reviewer
+		WHERE NOT EXISTS (
+			SELECT 1 FROM reviewer_assignments
+			WHERE pull_request_id = $1 AND user_id = reviewer AND unassigned_at IS NULL
+		)
+	`, pr.PullRequestId, pq.Array(pr.AssignedReviewers), api.ReviewerAssignmentActionManual, split.At)
+	if err != nil {
+		return fmt.Errorf("failed to add reviewer assignments: %w", translateError(err, nil))
+	}
+
+	if err := insertReviews(tx, split.Reviews); err != nil {
+		return err
+	}
+	if err := insertEscalations(tx, split.Escalations); err != nil {
+		return err
+	}
+
+	return insertOutboxEvents(tx, split.Events)
+}
+
+func (r *PullRequestRepository) FindAssignmentsByPR(prID string) ([]api.ReviewerAssignment, error) {
+	rows, err := r.db.Queryx(`
+		SELECT pull_request_id, user_id, action, replaced_user_id, assigned_at, unassigned_at
+		FROM reviewer_assignments
+		WHERE pull_request_id = $1
+		ORDER BY assigned_at, id
+	`, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reviewer assignments: %w", err)
+	}
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	assignments := []api.ReviewerAssignment{}
+	for rows.Next() {
+		var assignment api.ReviewerAssignment
+		err := rows.Scan(&assignment.PullRequestId, &assignment.UserId, &assignment.Action,
+			&assignment.ReplacedUserId, &assignment.AssignedAt, &assignment.UnassignedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer assignment: %w", err)
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments, rows.Err()
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
 )
 
 type TeamRepository struct {
@@ -26,21 +28,18 @@ func (r *TeamRepository) CreateTeam(team api.Team) error {
 		_ = tx.Rollback()
 	}(tx)
 
-	_, err = tx.Exec("INSERT INTO teams (team_name) VALUES ($1)", team.TeamName)
+	_, err = tx.Exec(`
+		INSERT INTO teams (team_name, selection_strategy, required_reviewers, fallback_teams, max_open_reviews, min_senior_reviewers,
+			review_timeout_minutes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, team.TeamName, team.SelectionStrategy, team.RequiredReviewers, pq.Array(fallbackTeams(team)), team.MaxOpenReviews,
+		team.MinSeniorReviewers, team.ReviewTimeoutMinutes)
 	if err != nil {
-		return fmt.Errorf("failed to create team: %w", err)
+		return fmt.Errorf("failed to create team: %w", translateError(err, nil))
 	}
 
-	for _, member := range team.Members {
-		_, err = tx.Exec(`
-			INSERT INTO users (user_id, username, team_name, is_active) 
-			VALUES ($1, $2, $3, $4)
-			ON CONFLICT (user_id) DO UPDATE SET 
-				username = $2, team_name = $3, is_active = $4
-		`, member.UserId, member.Username, team.TeamName, member.IsActive)
-		if err != nil {
-			return fmt.Errorf("failed to create/update user: %w", err)
-		}
+	if err := upsertMembers(tx, team); err != nil {
+		return err
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -49,8 +48,59 @@ func (r *TeamRepository) CreateTeam(team api.Team) error {
 	return nil
 }
 
+// UpdateTeam stores the settings of team. Members are not touched, so a
+// settings update cannot overwrite users changed since team was read.
 func (r *TeamRepository) UpdateTeam(team api.Team) error {
-	return r.CreateTeam(team)
+	result, err := r.db.Exec(`
+		UPDATE teams SET selection_strategy = $2, required_reviewers = $3, fallback_teams = $4, max_open_reviews = $5,
+			min_senior_reviewers = $6, review_timeout_minutes = $7
+		WHERE team_name = $1
+	`, team.TeamName, team.SelectionStrategy, team.RequiredReviewers, pq.Array(fallbackTeams(team)), team.MaxOpenReviews,
+		team.MinSeniorReviewers, team.ReviewTimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("failed to update team: %w", translateError(err, nil))
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrTeamNotFound
+	}
+	return nil
+}
+
+func fallbackTeams(team api.Team) []string {
+	if team.FallbackTeams == nil {
+		return []string{}
+	}
+	return *team.FallbackTeams
+}
+
+// upsertMembers creates or updates the member users and adds them to the
+// team. Memberships in other teams are kept, and so is the seniority of a
+// member given without one.
+func upsertMembers(tx *sqlx.Tx, team api.Team) error {
+	for _, member := range team.Members {
+		_, err := tx.Exec(`
+			INSERT INTO users (user_id, username, is_active, seniority) 
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id) DO UPDATE SET 
+				username = $2, is_active = $3, seniority = COALESCE($4, users.seniority)
+		`, member.UserId, member.Username, member.IsActive, member.Seniority)
+		if err != nil {
+			return fmt.Errorf("failed to create/update user: %w", translateError(err, nil))
+		}
+		_, err = tx.Exec(`
+			INSERT INTO team_memberships (team_name, user_id)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, team.TeamName, member.UserId)
+		if err != nil {
+			return fmt.Errorf("failed to add team membership: %w", translateError(err, nil))
+		}
+	}
+	return nil
 }
 
 func (r *TeamRepository) ExistTeamByName(name string) bool {
@@ -65,6 +115,20 @@ func (r *TeamRepository) ExistTeamByName(name string) bool {
 func (r *TeamRepository) FindTeamByName(name string) api.Team {
 	team := api.Team{TeamName: name}
 
+	var fallback []string
+	err := r.db.QueryRow(`
+		SELECT selection_strategy, required_reviewers, fallback_teams, max_open_reviews, min_senior_reviewers,
+			review_timeout_minutes, is_archived
+		FROM teams WHERE team_name = $1
+	`, name).Scan(&team.SelectionStrategy, &team.RequiredReviewers, pq.Array(&fallback), &team.MaxOpenReviews,
+		&team.MinSeniorReviewers, &team.ReviewTimeoutMinutes, &team.IsArchived)
+	if err != nil {
+		return api.Team{}
+	}
+	if len(fallback) > 0 {
+		team.FallbackTeams = &fallback
+	}
+
 	members, err := r.FindTeamMembersByName(name)
 	if err != nil {
 		return api.Team{}
@@ -76,7 +140,8 @@ func (r *TeamRepository) FindTeamByName(name string) api.Team {
 func (r *TeamRepository) FindTeamsByUser(userID string) ([]string, error) {
 	var teamNames []string
 	err := r.db.Select(&teamNames, `
-		SELECT DISTINCT team_name FROM users WHERE user_id = $1
+		SELECT team_name FROM team_memberships WHERE user_id = $1
+		ORDER BY joined_at, team_name
 	`, userID)
 	if err != nil {
 		return nil, err
@@ -87,9 +152,10 @@ func (r *TeamRepository) FindTeamsByUser(userID string) ([]string, error) {
 func (r *TeamRepository) FindTeamMembersByName(teamName string) ([]api.TeamMember, error) {
 	var members []api.TeamMember
 	err := r.db.Select(&members, `
-		SELECT u.user_id as "user_id", u.username, u.is_active 
+		SELECT u.user_id as "user_id", u.username, u.is_active, u.seniority
 		FROM users u
-		WHERE u.team_name = $1
+		JOIN team_memberships m ON m.user_id = u.user_id
+		WHERE m.team_name = $1
 		ORDER BY u.user_id
 	`, teamName)
 	if err != nil {
@@ -97,3 +163,171 @@ func (r *TeamRepository) FindTeamMembersByName(teamName string) ([]api.TeamMembe
 	}
 	return members, nil
 }
+
+func (r *TeamRepository) AddMembers(teamName string, members []api.TeamMember) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var exists bool
+	err = tx.QueryRow("SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", teamName).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check team: %w", err)
+	}
+	if !exists {
+		return domain.ErrTeamNotFound
+	}
+
+	if err := upsertMembers(tx, api.Team{TeamName: teamName, Members: members}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *TeamRepository) RemoveMembers(teamName string, userIDs []string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var exists bool
+	err = tx.QueryRow("SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", teamName).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check team: %w", err)
+	}
+	if !exists {
+		return domain.ErrTeamNotFound
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM team_memberships WHERE team_name = $1 AND user_id = ANY($2)
+	`, teamName, pq.Array(userIDs))
+	if err != nil {
+		return fmt.Errorf("failed to remove team members: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// RenameTeam copies the team row under the new name, moves the memberships,
+// pull requests and fallback references over and drops the old row, since
+// they reference teams by name.
+func (r *TeamRepository) RenameTeam(teamName string, newTeamName string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	result, err := tx.Exec(`
+		INSERT INTO teams (team_name, selection_strategy, required_reviewers, fallback_teams, max_open_reviews,
+			min_senior_reviewers, review_timeout_minutes, is_archived)
+		SELECT $2, selection_strategy, required_reviewers, fallback_teams, max_open_reviews,
+			min_senior_reviewers, review_timeout_minutes, is_archived
+		FROM teams WHERE team_name = $1
+	`, teamName, newTeamName)
+	if err != nil {
+		return fmt.Errorf("failed to rename team: %w", translateError(err, nil))
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrTeamNotFound
+	}
+
+	_, err = tx.Exec("UPDATE team_memberships SET team_name = $2 WHERE team_name = $1", teamName, newTeamName)
+	if err != nil {
+		return fmt.Errorf("failed to move team members: %w", err)
+	}
+	_, err = tx.Exec("UPDATE pull_requests SET team_name = $2 WHERE team_name = $1", teamName, newTeamName)
+	if err != nil {
+		return fmt.Errorf("failed to move pull requests: %w", err)
+	}
+	_, err = tx.Exec(`
+		UPDATE teams SET fallback_teams = array_replace(fallback_teams, $1, $2)
+		WHERE $1 = ANY(fallback_teams)
+	`, teamName, newTeamName)
+	if err != nil {
+		return fmt.Errorf("failed to update fallback teams: %w", err)
+	}
+	_, err = tx.Exec("DELETE FROM teams WHERE team_name = $1", teamName)
+	if err != nil {
+		return fmt.Errorf("failed to delete old team: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *TeamRepository) ArchiveTeam(teamName string) error {
+	result, err := r.db.Exec("UPDATE teams SET is_archived = true WHERE team_name = $1", teamName)
+	if err != nil {
+		return fmt.Errorf("failed to archive team: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrTeamNotFound
+	}
+	return nil
+}
+
+func (r *TeamRepository) DeleteTeam(teamName string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	_, err = tx.Exec("DELETE FROM team_memberships WHERE team_name = $1", teamName)
+	if err != nil {
+		return fmt.Errorf("failed to remove team members: %w", err)
+	}
+	_, err = tx.Exec(`
+		UPDATE teams SET fallback_teams = array_remove(fallback_teams, $1)
+		WHERE $1 = ANY(fallback_teams)
+	`, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to update fallback teams: %w", err)
+	}
+	result, err := tx.Exec("DELETE FROM teams WHERE team_name = $1", teamName)
+	if err != nil {
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrTeamNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
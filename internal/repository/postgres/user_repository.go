@@ -4,7 +4,10 @@ import (
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
 )
 
 type UserRepository struct {
@@ -17,41 +20,217 @@ func NewUserRepository(db *sqlx.DB) *UserRepository {
 	}
 }
 
+// userRow is a users row with the teams from team_memberships, in the order
+// they were joined, and the sorted tags from user_tags.
+type userRow struct {
+	UserId         string         `db:"user_id"`
+	Username       string         `db:"username"`
+	IsActive       bool           `db:"is_active"`
+	MaxOpenReviews *int           `db:"max_open_reviews"`
+	Seniority      *api.Seniority `db:"seniority"`
+	Teams          pq.StringArray `db:"teams"`
+	Tags           pq.StringArray `db:"tags"`
+}
+
+const selectUsers = `
+	SELECT u.user_id, u.username, u.is_active, u.max_open_reviews, u.seniority,
+		COALESCE(array_agg(m.team_name ORDER BY m.joined_at, m.team_name)
+			FILTER (WHERE m.team_name IS NOT NULL), '{}') as "teams",
+		ARRAY(SELECT t.tag FROM user_tags t WHERE t.user_id = u.user_id ORDER BY t.tag) as "tags"
+	FROM users u
+	LEFT JOIN team_memberships m ON m.user_id = u.user_id
+`
+
+func (row userRow) toUser() api.User {
+	user := api.User{
+		UserId:         row.UserId,
+		Username:       row.Username,
+		IsActive:       row.IsActive,
+		MaxOpenReviews: row.MaxOpenReviews,
+		Seniority:      row.Seniority,
+		Teams:          []string(row.Teams),
+	}
+	if len(user.Teams) > 0 {
+		user.TeamName = user.Teams[0]
+	}
+	if len(row.Tags) > 0 {
+		tags := []string(row.Tags)
+		user.Tags = &tags
+	}
+	return user
+}
+
+func (r *UserRepository) CreateUser(user api.User) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	_, err = tx.Exec(`
+		INSERT INTO users (user_id, username, is_active, max_open_reviews, seniority) VALUES ($1, $2, $3, $4, $5)
+	`, user.UserId, user.Username, user.IsActive, user.MaxOpenReviews, user.Seniority)
+	if err != nil {
+		return fmt.Errorf("failed to create user: %w", translateError(err, nil))
+	}
+	if err := setMemberships(tx, user.UserId, user.Teams); err != nil {
+		return err
+	}
+	if err := setTags(tx, user.UserId, user.Tags); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateUser(user api.User) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	result, err := tx.Exec(`
+		UPDATE users SET username = $2, is_active = $3, max_open_reviews = $4, seniority = $5 WHERE user_id = $1
+	`, user.UserId, user.Username, user.IsActive, user.MaxOpenReviews, user.Seniority)
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", translateError(err, nil))
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrUserNotFound
+	}
+	if err := setMemberships(tx, user.UserId, user.Teams); err != nil {
+		return err
+	}
+	if err := setTags(tx, user.UserId, user.Tags); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// setMemberships makes teams the only teams of userID. Kept memberships
+// keep their joined_at, new ones are joined in the given order.
+func setMemberships(tx *sqlx.Tx, userID string, teams []string) error {
+	_, err := tx.Exec(`
+		DELETE FROM team_memberships WHERE user_id = $1 AND NOT (team_name = ANY($2))
+	`, userID, pq.Array(teams))
+	if err != nil {
+		return fmt.Errorf("failed to remove team memberships: %w", err)
+	}
+	for _, teamName := range teams {
+		_, err := tx.Exec(`
+			INSERT INTO team_memberships (team_name, user_id, joined_at)
+			VALUES ($1, $2, clock_timestamp())
+			ON CONFLICT DO NOTHING
+		`, teamName, userID)
+		if err != nil {
+			return fmt.Errorf("failed to add team membership: %w", translateError(err, nil))
+		}
+	}
+	return nil
+}
+
+// setTags makes tags the only tags of userID.
+func setTags(tx *sqlx.Tx, userID string, tags *[]string) error {
+	var values []string
+	if tags != nil {
+		values = *tags
+	}
+	_, err := tx.Exec(`
+		DELETE FROM user_tags WHERE user_id = $1 AND NOT (tag = ANY($2))
+	`, userID, pq.Array(values))
+	if err != nil {
+		return fmt.Errorf("failed to remove user tags: %w", err)
+	}
+	for _, tag := range values {
+		_, err := tx.Exec(`
+			INSERT INTO user_tags (user_id, tag) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, userID, tag)
+		if err != nil {
+			return fmt.Errorf("failed to add user tag: %w", translateError(err, nil))
+		}
+	}
+	return nil
+}
+
 func (r *UserRepository) FindUserByID(userID string) (*api.User, error) {
-	var user api.User
-	err := r.db.Get(&user, `
-		SELECT user_id as "user_id", username, team_name as "team_name", is_active as "is_active"
-		FROM users WHERE user_id = $1
+	var row userRow
+	err := r.db.Get(&row, selectUsers+`
+		WHERE u.user_id = $1
+		GROUP BY u.user_id
 	`, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find user: %w", err)
+		return nil, fmt.Errorf("failed to find user: %w", translateError(err, domain.ErrUserNotFound))
 	}
+	user := row.toUser()
 	return &user, nil
 }
 
 func (r *UserRepository) UpdateUserStatus(userID string, status bool) error {
 	result, err := r.db.Exec("UPDATE users SET is_active = $1 WHERE user_id = $2", status, userID)
 	if err != nil {
-		return fmt.Errorf("failed to update user status: %w", err)
+		return fmt.Errorf("failed to update user status: %w", translateError(err, nil))
 	}
 	rows, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
 	if rows == 0 {
-		return fmt.Errorf("user not found")
+		return domain.ErrUserNotFound
 	}
 	return nil
 }
 
+func (r *UserRepository) ListUsers(filter repository.UserFilter) ([]api.User, error) {
+	var rows []userRow
+	err := r.db.Select(&rows, selectUsers+`
+		WHERE ($1 = '' OR EXISTS (
+			SELECT 1 FROM team_memberships f WHERE f.user_id = u.user_id AND f.team_name = $1
+		))
+		AND ($2::boolean IS NULL OR u.is_active = $2)
+		AND ($3 = '' OR EXISTS (
+			SELECT 1 FROM user_tags g WHERE g.user_id = u.user_id AND g.tag = $3
+		))
+		GROUP BY u.user_id
+		ORDER BY u.user_id
+	`, filter.TeamName, filter.IsActive, filter.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	users := make([]api.User, 0, len(rows))
+	for _, row := range rows {
+		users = append(users, row.toUser())
+	}
+	return users, nil
+}
+
 func (r *UserRepository) GetAllUsers() ([]api.User, error) {
-	var users []api.User
-	err := r.db.Select(&users, `
-		SELECT user_id as "user_id", username, team_name as "team_name", is_active as "is_active"
-		FROM users
+	var rows []userRow
+	err := r.db.Select(&rows, selectUsers+`
+		GROUP BY u.user_id
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get users: %w", err)
 	}
+	users := make([]api.User, 0, len(rows))
+	for _, row := range rows {
+		users = append(users, row.toUser())
+	}
 	return users, nil
 }
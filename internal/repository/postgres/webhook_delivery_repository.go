@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+)
+
+type WebhookDeliveryRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookDeliveryRepository(db *sqlx.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		db: db,
+	}
+}
+
+func (r *WebhookDeliveryRepository) SaveDelivery(source string, deliveryID string) error {
+	result, err := r.db.Exec(`
+		INSERT INTO webhook_deliveries (source, delivery_id, received_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (source, delivery_id) DO NOTHING
+	`, source, deliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrDeliveryExists
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) DeleteDelivery(source string, deliveryID string) error {
+	_, err := r.db.Exec(
+		"DELETE FROM webhook_deliveries WHERE source = $1 AND delivery_id = $2",
+		source, deliveryID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook delivery: %w", err)
+	}
+	return nil
+}
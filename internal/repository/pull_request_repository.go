@@ -1,11 +1,61 @@
 package repository
 
-import "github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+import (
+	"time"
 
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+)
+
+// PullRequestRepository stores pull requests. UpdatePR is a compare-and-swap:
+// it succeeds only if the stored version equals pr.Version, and increments it;
+// otherwise it returns domain.ErrVersionConflict.
 type PullRequestRepository interface {
-	CreatePR(pr api.PullRequest) error
+	CreatePR(pr api.PullRequest, records ...Record) error
 	FindPRByID(prID string) (*api.PullRequest, error)
-	UpdatePR(pr api.PullRequest) error
+	UpdatePR(pr api.PullRequest, records ...Record) error
 	FindPRsByReviewer(userID string) ([]api.PullRequest, error)
+	ListPRs(filter PRFilter) ([]api.PullRequest, error)
 	GetAllPRs() ([]api.PullRequest, error)
+	CountOpenReviewsByUsers(userIDs []string) (map[string]int, error)
+	CountRecentPairings(authorID string, userIDs []string, since time.Time) (map[string]int, error)
+	FindAssignmentsByPR(prID string) ([]api.ReviewerAssignment, error)
+	FindReviewsByPR(prID string) ([]api.ReviewDecision, error)
+	FindEscalationsByPR(prID string) ([]api.ReviewEscalation, error)
+}
+
+type PRSortField string
+
+const (
+	PRSortCreatedAt PRSortField = "created_at"
+	PRSortName      PRSortField = "pull_request_name"
+)
+
+// PRFilter selects a page of pull requests for ListPRs. Zero fields match
+// everything. Ranges include From and exclude To; Name matches a
+// case-insensitive substring of the PR name; QueuedOnly keeps the PRs that
+// wait for reviewer capacity. PRs are ordered by SortBy and then by
+// pull_request_id, and the page starts right after After.
+type PRFilter struct {
+	AuthorID    string
+	ReviewerID  string
+	TeamName    string
+	Status      api.PullRequestStatus
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+	Name        string
+	QueuedOnly  bool
+
+	SortBy     PRSortField
+	Descending bool
+	After      *PRCursor
+	Limit      int
+}
+
+// PRCursor is the sort position of the last PR of a page.
+type PRCursor struct {
+	CreatedAt     time.Time `json:"created_at"`
+	Name          string    `json:"name"`
+	PullRequestID string    `json:"id"`
 }
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+)
+
+// Record is written in the same transaction as the pull request it is
+// passed with.
+type Record interface {
+	isRecord()
+}
+
+// AssignmentRecord is a reviewer assignment history entry.
+type AssignmentRecord api.ReviewerAssignment
+
+// ReviewRecord is a review decision submitted by a reviewer.
+type ReviewRecord api.ReviewDecision
+
+// EscalationRecord is a reminder or reassignment of an overdue reviewer.
+type EscalationRecord api.ReviewEscalation
+
+// ChangeTime is when the change it is passed with happened. It stamps the
+// history the repository writes on its own: unassigned_at of reviewers
+// taken off and assigned_at of reviewers logged as manual. Without it the
+// current time is used.
+type ChangeTime time.Time
+
+func (OutboxEvent) isRecord()      {}
+func (AssignmentRecord) isRecord() {}
+func (ReviewRecord) isRecord()     {}
+func (EscalationRecord) isRecord() {}
+func (ChangeTime) isRecord()       {}
+
+// Records holds records sorted by kind and the time of the change.
+type Records struct {
+	Events      []OutboxEvent
+	Assignments []api.ReviewerAssignment
+	Reviews     []api.ReviewDecision
+	Escalations []api.ReviewEscalation
+	At          time.Time
+}
+
+// SplitRecords sorts records by kind, keeping their order.
+func SplitRecords(records []Record) Records {
+	split := Records{At: time.Now()}
+	for _, record := range records {
+		switch r := record.(type) {
+		case OutboxEvent:
+			split.Events = append(split.Events, r)
+		case AssignmentRecord:
+			split.Assignments = append(split.Assignments, api.ReviewerAssignment(r))
+		case ReviewRecord:
+			split.Reviews = append(split.Reviews, api.ReviewDecision(r))
+		case EscalationRecord:
+			split.Escalations = append(split.Escalations, api.ReviewEscalation(r))
+		case ChangeTime:
+			split.At = time.Time(r)
+		}
+	}
+	return split
+}
@@ -2,6 +2,9 @@ package repository
 
 import "github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
 
+// TeamRepository stores teams and their members. A user can belong to any
+// number of teams; adding, removing or deleting only changes membership in
+// the given team. UpdateTeam only changes team settings.
 type TeamRepository interface {
 	CreateTeam(team api.Team) error
 	UpdateTeam(team api.Team) error
@@ -9,4 +12,9 @@ type TeamRepository interface {
 	FindTeamByName(name string) api.Team
 	FindTeamsByUser(userID string) ([]string, error)
 	FindTeamMembersByName(teamName string) ([]api.TeamMember, error)
+	AddMembers(teamName string, members []api.TeamMember) error
+	RemoveMembers(teamName string, userIDs []string) error
+	RenameTeam(teamName string, newTeamName string) error
+	ArchiveTeam(teamName string) error
+	DeleteTeam(teamName string) error
 }
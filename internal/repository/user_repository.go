@@ -1,9 +1,35 @@
 package repository
 
-import "github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+import (
+	"time"
 
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+)
+
+// UserFilter narrows ListUsers; zero fields match every user.
+type UserFilter struct {
+	TeamName string
+	Tag      string
+	IsActive *bool
+}
+
+// UserRepository stores users together with the teams they belong to and
+// their tags. UpdateUser replaces the username, activity status, open review
+// limit and the whole team and tag lists.
+//
+// Away periods cover StartsAt up to, but not including, EndsAt.
+// FindAwayPeriodsAt returns the periods covering at, ordered by id.
 type UserRepository interface {
+	CreateUser(user api.User) error
 	FindUserByID(userID string) (*api.User, error)
+	UpdateUser(user api.User) error
 	UpdateUserStatus(userID string, status bool) error
+	ListUsers(filter UserFilter) ([]api.User, error)
 	GetAllUsers() ([]api.User, error)
+
+	CreateAwayPeriod(period api.AwayPeriod) (*api.AwayPeriod, error)
+	FindAwayPeriodsByUser(userID string) ([]api.AwayPeriod, error)
+	FindAwayPeriodsAt(at time.Time) ([]api.AwayPeriod, error)
+	MarkAwayPeriodHandedOff(periodID int64, at time.Time) error
+	DeleteAwayPeriod(periodID int64) error
 }
@@ -0,0 +1,6 @@
+package repository
+
+type WebhookDeliveryRepository interface {
+	SaveDelivery(source string, deliveryID string) error
+	DeleteDelivery(source string, deliveryID string) error
+}
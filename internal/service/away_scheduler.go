@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const defaultAwayCheckInterval = time.Minute
+
+// AwayScheduler hands off the open reviews of users whose away period has
+// started, once per period. Nothing has to happen when a period ends: away
+// users are left out of selection only while the clock is inside a period.
+type AwayScheduler struct {
+	prService *PullRequestService
+	interval  time.Duration
+}
+
+func NewAwayScheduler(prService *PullRequestService, interval time.Duration) *AwayScheduler {
+	if interval <= 0 {
+		interval = defaultAwayCheckInterval
+	}
+	return &AwayScheduler{
+		prService: prService,
+		interval:  interval,
+	}
+}
+
+// Run hands off reviews every interval until ctx is done.
+func (s *AwayScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.HandOffStarted(); err != nil {
+			slog.Error("Error handing off reviews of away users", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// HandOffStarted hands off the reviews of every started period that asks for
+// it and has not been handed off yet, and returns how many reviews moved. A
+// period that fails is logged and tried again on the next run.
+func (s *AwayScheduler) HandOffStarted() (int, error) {
+	userRepository := s.prService.userRepository
+	now := s.prService.clock.Now()
+	periods, err := userRepository.FindAwayPeriodsAt(now)
+	if err != nil {
+		return 0, err
+	}
+
+	handedOff := 0
+	for _, period := range periods {
+		if !period.HandOffReviews || period.HandedOffAt != nil {
+			continue
+		}
+		released, err := s.prService.HandOffAwayReviews(period.UserId, period.DelegateId)
+		handedOff += released
+		if err != nil {
+			slog.Warn("Skipping away hand-off", "away_period_id", period.Id, "user_id", period.UserId, "error", err)
+			continue
+		}
+		if err := userRepository.MarkAwayPeriodHandedOff(period.Id, now); err != nil {
+			return handedOff, err
+		}
+	}
+	return handedOff, nil
+}
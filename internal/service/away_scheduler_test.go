@@ -0,0 +1,96 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository/inmemory"
+)
+
+func TestAwayPeriodsSkipSelectionAndHandOffReviews(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepositoryWithUsers(userRepo)
+	clock := &fakeClock{now: time.Date(2025, 7, 1, 8, 0, 0, 0, time.UTC)}
+	prService := NewPullRequestService(prRepo, teamRepo, userRepo, WithClock(clock))
+	userService := NewUserService(userRepo, teamRepo)
+	scheduler := NewAwayScheduler(prService, 0)
+
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+		{UserId: "u4", Username: "Diana", IsActive: true},
+	}})
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		TeamName:          "backend",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2"},
+	})
+
+	start := clock.now.Add(time.Hour)
+	delegate := "u4"
+	if _, err := userService.AddAwayPeriod(api.AwayPeriod{UserId: "u2", StartsAt: start, EndsAt: start}); !errors.Is(err, domain.ErrInvalidAwayPeriod) {
+		t.Errorf("Expected invalid away period error, got %v", err)
+	}
+	self := "u2"
+	if _, err := userService.AddAwayPeriod(api.AwayPeriod{UserId: "u2", StartsAt: start, EndsAt: start.Add(time.Hour), DelegateId: &self}); !errors.Is(err, domain.ErrInvalidDelegate) {
+		t.Errorf("Expected invalid delegate error, got %v", err)
+	}
+	_, _ = userService.AddAwayPeriod(api.AwayPeriod{
+		UserId: "u2", StartsAt: start, EndsAt: start.Add(48 * time.Hour), DelegateId: &delegate, HandOffReviews: true,
+	})
+	_, _ = userService.AddAwayPeriod(api.AwayPeriod{UserId: "u3", StartsAt: start, EndsAt: start.Add(24 * time.Hour)})
+
+	if members, _ := prService.GetActiveTeamMembers("u1"); len(members) != 3 {
+		t.Errorf("Expected everyone available before the periods start, got %v", members)
+	}
+	if handedOff, _ := scheduler.HandOffStarted(); handedOff != 0 {
+		t.Errorf("Expected no hand-off before the period starts, got %d", handedOff)
+	}
+
+	clock.now = start
+	members, _ := prService.GetActiveTeamMembers("u1")
+	if len(members) != 1 || members[0].UserId != "u4" {
+		t.Errorf("Expected only u4 available, got %v", members)
+	}
+	if handedOff, _ := scheduler.HandOffStarted(); handedOff != 1 {
+		t.Fatalf("Expected one review handed off, got %d", handedOff)
+	}
+	if handedOff, _ := scheduler.HandOffStarted(); handedOff != 0 {
+		t.Errorf("Expected the hand-off to happen once, got %d", handedOff)
+	}
+	pr, _ := prService.FindPRByID("pr-1")
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u4" {
+		t.Errorf("Expected the review handed to delegate u4, got %v", pr.AssignedReviewers)
+	}
+	history, _ := prService.GetHistory("pr-1")
+	if last := history[len(history)-1]; last.UserId != "u4" || last.Action != api.ReviewerAssignmentActionAway {
+		t.Errorf("Expected u4 logged as away, got %+v", last)
+	}
+	periods, _ := userService.ListAwayPeriods("u2")
+	if len(periods) != 1 || periods[0].HandedOffAt == nil || !periods[0].HandedOffAt.Equal(start) {
+		t.Errorf("Expected the period marked handed off, got %+v", periods)
+	}
+
+	clock.now = start.Add(24 * time.Hour)
+	_, newReviewer, err := prService.ReassignReviewer("pr-1", "u4")
+	if err != nil || *newReviewer != "u3" {
+		t.Errorf("Expected u3 back after the period ended, got %v %v", newReviewer, err)
+	}
+
+	if err := userService.DeleteAwayPeriod(periods[0].Id); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if members, _ := prService.GetActiveTeamMembers("u1"); len(members) != 3 {
+		t.Errorf("Expected u2 available once the period is deleted, got %v", members)
+	}
+	if err := userService.DeleteAwayPeriod(periods[0].Id); !errors.Is(err, domain.ErrAwayPeriodNotFound) {
+		t.Errorf("Expected away period not found, got %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+package service
+
+import "time"
+
+// Clock tells the current time. Services read time through it so that tests
+// can move it by hand.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
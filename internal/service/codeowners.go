@@ -0,0 +1,147 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+)
+
+type ownerKind int
+
+const (
+	ownerUser ownerKind = iota
+	ownerTeam
+	ownerEmail
+)
+
+type owner struct {
+	kind ownerKind
+	name string
+}
+
+// ParseCodeowners reads a file in the GitHub CODEOWNERS format: one pattern
+// per line followed by its owners, "#" starts a comment.
+func ParseCodeowners(content string) ([]api.OwnershipRule, error) {
+	var rules []api.OwnershipRule
+	for lineNumber, line := range strings.Split(content, "\n") {
+		if idx := strings.Index(line, "#"); idx >= 0 && (idx == 0 || line[idx-1] != '\\') {
+			line = line[:idx]
+		}
+		fields := strings.Fields(strings.ReplaceAll(line, `\#`, "#"))
+		if len(fields) == 0 {
+			continue
+		}
+
+		rule := api.OwnershipRule{Pattern: fields[0], Owners: fields[1:]}
+		if err := validateOwnershipRule(rule); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber+1, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func validateOwnershipRule(rule api.OwnershipRule) error {
+	if _, err := compileOwnershipPattern(rule.Pattern); err != nil {
+		return err
+	}
+	for _, value := range rule.Owners {
+		if _, err := parseOwner(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseOwner accepts "@user_id" for a user, "@org/team_name" for a team and
+// "name@example.com" for an email owner.
+func parseOwner(value string) (owner, error) {
+	if local, domain, ok := strings.Cut(value, "@"); ok && local != "" && domain != "" &&
+		!strings.ContainsAny(domain, "@/") {
+		return owner{kind: ownerEmail, name: value}, nil
+	}
+	if !strings.HasPrefix(value, "@") || len(value) == 1 {
+		return owner{}, fmt.Errorf("unsupported owner %q", value)
+	}
+	name := value[1:]
+	if idx := strings.Index(name, "/"); idx >= 0 {
+		team := name[idx+1:]
+		if team == "" || strings.Contains(team, "/") {
+			return owner{}, fmt.Errorf("unsupported owner %q", value)
+		}
+		return owner{kind: ownerTeam, name: team}, nil
+	}
+	return owner{kind: ownerUser, name: name}, nil
+}
+
+// compileOwnershipPattern translates a CODEOWNERS pattern into a regexp over
+// slash-separated paths relative to the repository root. Patterns without a
+// slash match at any depth, a trailing slash matches everything below the
+// directory, "*" stays inside one path segment and "**" crosses segments.
+func compileOwnershipPattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" || strings.HasPrefix(pattern, "!") || strings.Contains(pattern, "[") {
+		return nil, fmt.Errorf("unsupported pattern %q", pattern)
+	}
+
+	directory := strings.HasSuffix(pattern, "/")
+	trimmed := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(trimmed, "/")
+	trimmed = strings.TrimPrefix(trimmed, "/")
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	if !anchored && !strings.HasPrefix(trimmed, "**") {
+		expr.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(trimmed); i++ {
+		switch {
+		case strings.HasPrefix(trimmed[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(trimmed[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case trimmed[i] == '*':
+			expr.WriteString("[^/]*")
+		case trimmed[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(trimmed[i : i+1]))
+		}
+	}
+
+	lastSegment := trimmed[strings.LastIndex(trimmed, "/")+1:]
+	switch {
+	case directory:
+		expr.WriteString("/.*")
+	case !strings.Contains(lastSegment, "*") || trimmed == "*":
+		expr.WriteString("(?:/.*)?")
+	}
+	expr.WriteString("$")
+
+	return regexp.Compile(expr.String())
+}
+
+func normalizeChangedFile(path string) string {
+	path = strings.TrimPrefix(path, "./")
+	return strings.TrimPrefix(path, "/")
+}
+
+// matchOwnershipRule returns the last rule matching path, as GitHub does, or
+// nil when no rule matches.
+func matchOwnershipRule(rules []api.OwnershipRule, path string) *api.OwnershipRule {
+	path = normalizeChangedFile(path)
+	for i := len(rules) - 1; i >= 0; i-- {
+		re, err := compileOwnershipPattern(rules[i].Pattern)
+		if err != nil {
+			continue
+		}
+		if re.MatchString(path) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestParseCodeowners(t *testing.T) {
+	content := `# Default owners
+*            @acme/backend
+
+/docs/       @acme/docs @u7   # documentation
+*.sql        @u9
+`
+	rules, err := ParseCodeowners(content)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rules) != 3 {
+		t.Fatalf("Expected 3 rules, got %d", len(rules))
+	}
+	if rules[1].Pattern != "/docs/" || len(rules[1].Owners) != 2 || rules[1].Owners[1] != "@u7" {
+		t.Errorf("Unexpected second rule: %+v", rules[1])
+	}
+
+	rules, err = ParseCodeowners("docs/* dev@example.com @u7")
+	if err != nil {
+		t.Fatalf("Expected email owner to be accepted, got %v", err)
+	}
+	if len(rules) != 1 || rules[0].Owners[0] != "dev@example.com" {
+		t.Errorf("Unexpected rule: %+v", rules)
+	}
+
+	_, err = ParseCodeowners("*.go dev@")
+	if err == nil {
+		t.Fatal("Expected error for malformed owner")
+	}
+}
+
+func TestMatchOwnershipRule(t *testing.T) {
+	rules, err := ParseCodeowners(`
+*              @acme/backend
+*.js           @acme/frontend
+/docs/         @acme/docs
+build/logs     @u1
+apps/          @u2
+scripts/*      @u3
+**/migrations  @u4
+`)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	cases := map[string]string{
+		"main.go":                       "*",
+		"web/app.js":                    "*.js",
+		"docs/index.md":                 "/docs/",
+		"web/docs/index.md":             "*",
+		"build/logs/today.log":          "build/logs",
+		"service/apps/api.go":           "apps/",
+		"scripts/run.sh":                "scripts/*",
+		"scripts/nested/run.sh":         "*",
+		"db/migrations/001_init.sql":    "**/migrations",
+		"./docs/guide/getting-started":  "/docs/",
+		"/service/apps/deep/handler.go": "apps/",
+	}
+	for path, want := range cases {
+		rule := matchOwnershipRule(rules, path)
+		if rule == nil {
+			t.Errorf("%s: expected rule %q, got none", path, want)
+			continue
+		}
+		if rule.Pattern != want {
+			t.Errorf("%s: expected rule %q, got %q", path, want, rule.Pattern)
+		}
+	}
+}
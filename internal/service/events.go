@@ -0,0 +1,112 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
+)
+
+type EventType string
+
+const (
+	EventPullRequestCreated  EventType = "pull_request.created"
+	EventPullRequestMerged   EventType = "pull_request.merged"
+	EventPullRequestClosed   EventType = "pull_request.closed"
+	EventPullRequestReopened EventType = "pull_request.reopened"
+	EventPullRequestReady    EventType = "pull_request.ready_for_review"
+	EventReviewSubmitted     EventType = "pull_request.review_submitted"
+	EventReviewReminder      EventType = "pull_request.review_reminder"
+	EventReviewersChanged    EventType = "pull_request.reviewers_changed"
+)
+
+// Event describes a change to a pull request. AddedReviewers and
+// RemovedReviewers hold the difference made by this change only;
+// OverdueReviewer is the reviewer a reminder is for.
+type Event struct {
+	ID               string          `json:"id"`
+	Type             EventType       `json:"type"`
+	OccurredAt       time.Time       `json:"occurred_at"`
+	PullRequest      api.PullRequest `json:"pull_request"`
+	AddedReviewers   []string        `json:"added_reviewers"`
+	RemovedReviewers []string        `json:"removed_reviewers"`
+	OverdueReviewer  string          `json:"overdue_reviewer,omitempty"`
+}
+
+func newEvent(eventType EventType, occurredAt time.Time, pr api.PullRequest, added []string, removed []string) Event {
+	if added == nil {
+		added = []string{}
+	}
+	if removed == nil {
+		removed = []string{}
+	}
+	return Event{
+		ID:               uuid.NewString(),
+		Type:             eventType,
+		OccurredAt:       occurredAt.UTC(),
+		PullRequest:      pr,
+		AddedReviewers:   added,
+		RemovedReviewers: removed,
+	}
+}
+
+// newOutboxEvent encodes a new Event for the repository's outbox.
+func newOutboxEvent(eventType EventType, occurredAt time.Time, pr api.PullRequest, added []string, removed []string) (repository.OutboxEvent, error) {
+	return encodeOutboxEvent(newEvent(eventType, occurredAt, pr, added, removed))
+}
+
+func encodeOutboxEvent(event Event) (repository.OutboxEvent, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return repository.OutboxEvent{}, fmt.Errorf("failed to encode event: %w", err)
+	}
+	return repository.OutboxEvent{
+		ID:        event.ID,
+		Type:      string(event.Type),
+		Payload:   payload,
+		CreatedAt: event.OccurredAt,
+	}, nil
+}
+
+// EventHandler handles an event and returns an error when it has to be
+// handled again later.
+type EventHandler func(event Event) error
+
+// EventBus delivers published events synchronously to every subscriber, in
+// subscription order.
+type EventBus struct {
+	mu       sync.RWMutex
+	handlers []EventHandler
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+func (b *EventBus) Subscribe(handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers = append(b.handlers, handler)
+}
+
+// Publish runs every handler, also after one fails, and returns the joined
+// errors of the handlers that failed.
+func (b *EventBus) Publish(event Event) error {
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
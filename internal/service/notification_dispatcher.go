@@ -0,0 +1,204 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/config"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
+)
+
+const (
+	defaultNotificationAttempts = 5
+	defaultNotificationBackoff  = time.Second
+	defaultNotificationTimeout  = 5 * time.Second
+	defaultDeliveriesLimit      = 100
+)
+
+// NotificationDispatcher posts events to the configured subscribers. Every
+// attempt is written to the delivery log; network errors, 429 and 5xx
+// responses are retried with exponential backoff.
+type NotificationDispatcher struct {
+	subscribers        []config.NotificationSubscriberConfig
+	deliveryRepository repository.NotificationDeliveryRepository
+	client             *http.Client
+	maxAttempts        int
+	initialBackoff     time.Duration
+	clock              Clock
+}
+
+type NotificationDispatcherOption func(*NotificationDispatcher)
+
+// WithDeliveryClock replaces the system clock used for delivery log
+// timestamps.
+func WithDeliveryClock(clock Clock) NotificationDispatcherOption {
+	return func(d *NotificationDispatcher) {
+		d.clock = clock
+	}
+}
+
+func NewNotificationDispatcher(
+	cfg config.NotificationsConfig,
+	deliveryRepository repository.NotificationDeliveryRepository,
+	opts ...NotificationDispatcherOption,
+) *NotificationDispatcher {
+	d := &NotificationDispatcher{
+		subscribers:        cfg.Subscribers,
+		deliveryRepository: deliveryRepository,
+		client:             &http.Client{Timeout: cfg.Timeout},
+		maxAttempts:        cfg.MaxAttempts,
+		initialBackoff:     cfg.InitialBackoff,
+		clock:              systemClock{},
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.client.Timeout <= 0 {
+		d.client.Timeout = defaultNotificationTimeout
+	}
+	if d.maxAttempts <= 0 {
+		d.maxAttempts = defaultNotificationAttempts
+	}
+	if d.initialBackoff <= 0 {
+		d.initialBackoff = defaultNotificationBackoff
+	}
+	return d
+}
+
+// Dispatch delivers the event to every interested subscriber that has not
+// accepted it yet and waits for the deliveries. It returns an error when a
+// subscriber is still to be retried, so that the outbox keeps the event. It
+// is meant to be subscribed to an EventBus.
+func (d *NotificationDispatcher) Dispatch(event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		slog.Error("Error encoding event", "event_id", event.ID, "error", err)
+		return nil
+	}
+	delivered, err := d.deliveryRepository.FindDeliveredSubscribers(event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find delivered subscribers: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(d.subscribers))
+	for i, subscriber := range d.subscribers {
+		if !subscribedTo(subscriber, event.Type) || slices.Contains(delivered, subscriber.URL) {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, subscriber config.NotificationSubscriberConfig) {
+			defer wg.Done()
+			errs[i] = d.deliver(subscriber, event, body)
+		}(i, subscriber)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (d *NotificationDispatcher) GetDeliveries(eventID string, limit int) ([]api.NotificationDelivery, error) {
+	if limit <= 0 {
+		limit = defaultDeliveriesLimit
+	}
+	return d.deliveryRepository.FindDeliveries(eventID, limit)
+}
+
+// deliver posts the event until the subscriber accepts it or attempts run
+// out. A subscriber that rejects the event with a non-retryable status gets
+// it no more; running out of attempts is returned as an error.
+func (d *NotificationDispatcher) deliver(subscriber config.NotificationSubscriberConfig, event Event, body []byte) error {
+	backoff := d.initialBackoff
+	for attempt := 1; ; attempt++ {
+		delivery := api.NotificationDelivery{
+			EventId:       event.ID,
+			EventType:     string(event.Type),
+			SubscriberUrl: subscriber.URL,
+			Attempt:       attempt,
+			CreatedAt:     d.clock.Now().UTC(),
+		}
+
+		statusCode, err := d.send(subscriber, event, body)
+		retry := true
+		if err != nil {
+			message := err.Error()
+			delivery.Error = &message
+		} else {
+			delivery.StatusCode = &statusCode
+			delivery.Success = statusCode >= 200 && statusCode < 300
+			retry = statusCode == http.StatusTooManyRequests || statusCode >= 500
+		}
+
+		if err := d.deliveryRepository.SaveDelivery(delivery); err != nil {
+			slog.Error("Error saving notification delivery", "event_id", event.ID, "error", err)
+		}
+		if delivery.Success {
+			return nil
+		}
+		if !retry {
+			slog.Warn("Notification was rejected", "event_id", event.ID, "url", subscriber.URL, "attempts", attempt)
+			return nil
+		}
+		if attempt >= d.maxAttempts {
+			return fmt.Errorf("notification %s to %s was not delivered after %d attempts", event.ID, subscriber.URL, attempt)
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (d *NotificationDispatcher) send(subscriber config.NotificationSubscriberConfig, event Event, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, subscriber.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Reviewer-Event", string(event.Type))
+	req.Header.Set("X-Reviewer-Delivery", event.ID)
+	if subscriber.Secret != "" {
+		req.Header.Set("X-Reviewer-Signature", signPayload(subscriber.Secret, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func(body io.ReadCloser) {
+		_, _ = io.Copy(io.Discard, body)
+		_ = body.Close()
+	}(resp.Body)
+
+	return resp.StatusCode, nil
+}
+
+// signPayload returns the "sha256=<hex>" HMAC of body, the same scheme
+// GitHub uses for X-Hub-Signature-256.
+func signPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func subscribedTo(subscriber config.NotificationSubscriberConfig, eventType EventType) bool {
+	if len(subscriber.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range subscriber.Events {
+		if subscribed == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/config"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository/inmemory"
+)
+
+type recordedNotification struct {
+	eventType string
+	signature string
+	event     Event
+	body      []byte
+}
+
+// notificationReceiver answers with the given status codes in order and
+// repeats the last one.
+type notificationReceiver struct {
+	mu       sync.Mutex
+	statuses []int
+	received []recordedNotification
+}
+
+func (rcv *notificationReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	var event Event
+	_ = json.Unmarshal(body, &event)
+
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	rcv.received = append(rcv.received, recordedNotification{
+		eventType: r.Header.Get("X-Reviewer-Event"),
+		signature: r.Header.Get("X-Reviewer-Signature"),
+		event:     event,
+		body:      body,
+	})
+	status := rcv.statuses[len(rcv.statuses)-1]
+	if len(rcv.received) <= len(rcv.statuses) {
+		status = rcv.statuses[len(rcv.received)-1]
+	}
+	w.WriteHeader(status)
+}
+
+var testDeliveryTime = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func newTestDispatcher(subscribers ...config.NotificationSubscriberConfig) (*NotificationDispatcher, *inmemory.NotificationDeliveryRepository) {
+	deliveryRepo := inmemory.NewNotificationDeliveryRepository()
+	dispatcher := NewNotificationDispatcher(config.NotificationsConfig{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		Subscribers:    subscribers,
+	}, deliveryRepo, WithDeliveryClock(&fakeClock{now: testDeliveryTime}))
+	return dispatcher, deliveryRepo
+}
+
+func TestNotificationDispatcherRetriesWithBackoff(t *testing.T) {
+	receiver := &notificationReceiver{statuses: []int{http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusOK}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	dispatcher, deliveryRepo := newTestDispatcher(config.NotificationSubscriberConfig{URL: server.URL, Secret: "s3cret"})
+	event := newEvent(EventPullRequestMerged, time.Now(), api.PullRequest{PullRequestId: "pr-1"}, nil, nil)
+	_ = dispatcher.Dispatch(event)
+
+	if len(receiver.received) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(receiver.received))
+	}
+	last := receiver.received[2]
+	if last.signature != signPayload("s3cret", last.body) {
+		t.Errorf("Expected valid signature, got %s", last.signature)
+	}
+	if last.eventType != string(EventPullRequestMerged) {
+		t.Errorf("Expected event type header %s, got %s", EventPullRequestMerged, last.eventType)
+	}
+
+	deliveries, _ := deliveryRepo.FindDeliveries(event.ID, 10)
+	if len(deliveries) != 3 {
+		t.Fatalf("Expected 3 logged attempts, got %d", len(deliveries))
+	}
+	if !deliveries[0].Success || deliveries[0].Attempt != 3 || !deliveries[0].CreatedAt.Equal(testDeliveryTime) {
+		t.Errorf("Expected the last attempt to succeed at the clock time, got %+v", deliveries[0])
+	}
+	if deliveries[2].Success || *deliveries[2].StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected the first attempt to fail with 500, got %+v", deliveries[2])
+	}
+}
+
+func TestNotificationDispatcherDoesNotRetryClientErrors(t *testing.T) {
+	receiver := &notificationReceiver{statuses: []int{http.StatusBadRequest}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	dispatcher, deliveryRepo := newTestDispatcher(config.NotificationSubscriberConfig{URL: server.URL})
+	event := newEvent(EventPullRequestMerged, time.Now(), api.PullRequest{PullRequestId: "pr-1"}, nil, nil)
+	_ = dispatcher.Dispatch(event)
+
+	deliveries, _ := deliveryRepo.FindDeliveries(event.ID, 10)
+	if len(deliveries) != 1 || deliveries[0].Success {
+		t.Errorf("Expected a single failed attempt, got %+v", deliveries)
+	}
+}
+
+func TestPullRequestEventsReachSubscribers(t *testing.T) {
+	receiver := &notificationReceiver{statuses: []int{http.StatusOK}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	dispatcher, _ := newTestDispatcher(config.NotificationSubscriberConfig{
+		URL:    server.URL,
+		Events: []string{string(EventPullRequestCreated), string(EventReviewersChanged)},
+	})
+	eventBus := NewEventBus()
+	eventBus.Subscribe(dispatcher.Dispatch)
+
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepository()
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+	relay := NewOutboxRelay(prRepo, eventBus, time.Millisecond)
+
+	requiredReviewers := 1
+	members := []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+	}
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", RequiredReviewers: &requiredReviewers, Members: members})
+	for _, member := range members {
+		userRepo.AddUser(&api.User{UserId: member.UserId, Username: member.Username, IsActive: true, TeamName: "backend"})
+	}
+
+	pr := &api.PullRequest{PullRequestId: "pr-1", PullRequestName: "Test PR", AuthorId: "u1"}
+	if err := service.CreatePR(pr, CreatePROptions{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, _ = relay.RelayPending()
+	oldReviewer := pr.AssignedReviewers[0]
+	_, newReviewer, err := service.ReassignReviewer("pr-1", oldReviewer)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, _ = relay.RelayPending()
+	if _, err := service.MergePR("pr-1", false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, _ = relay.RelayPending()
+
+	if len(receiver.received) != 2 {
+		t.Fatalf("Expected 2 notifications (merge is filtered out), got %d", len(receiver.received))
+	}
+	created := receiver.received[0].event
+	if created.Type != EventPullRequestCreated || len(created.AddedReviewers) != 1 || created.AddedReviewers[0] != oldReviewer {
+		t.Errorf("Unexpected created event %+v", created)
+	}
+	changed := receiver.received[1].event
+	if changed.Type != EventReviewersChanged ||
+		len(changed.AddedReviewers) != 1 || changed.AddedReviewers[0] != *newReviewer ||
+		len(changed.RemovedReviewers) != 1 || changed.RemovedReviewers[0] != oldReviewer {
+		t.Errorf("Unexpected reviewers_changed event %+v", changed)
+	}
+	if changed.PullRequest.PullRequestId != "pr-1" {
+		t.Errorf("Expected payload to include the PR, got %+v", changed.PullRequest)
+	}
+}
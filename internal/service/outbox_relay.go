@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
+)
+
+const (
+	defaultRelayInterval = time.Second
+	relayBatchSize       = 100
+)
+
+// OutboxRelay publishes the events written to the outbox on the event bus
+// and marks them published once every handler has succeeded. An event whose
+// handler failed stays pending and is published again on the next run, also
+// after a restart. Delivery is at least once: subscribers can tell repeats
+// apart by the event id.
+type OutboxRelay struct {
+	outboxRepository repository.OutboxRepository
+	eventBus         *EventBus
+	interval         time.Duration
+}
+
+func NewOutboxRelay(outboxRepository repository.OutboxRepository, eventBus *EventBus, interval time.Duration) *OutboxRelay {
+	if interval <= 0 {
+		interval = defaultRelayInterval
+	}
+	return &OutboxRelay{
+		outboxRepository: outboxRepository,
+		eventBus:         eventBus,
+		interval:         interval,
+	}
+}
+
+// Run relays pending events every interval until ctx is done.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.RelayPending(); err != nil {
+			slog.Error("Error relaying outbox events", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayPending publishes all pending events in batches and returns how many
+// were published. A batch with an event that failed ends the run, so that
+// the event is retried on the next one.
+func (r *OutboxRelay) RelayPending() (int, error) {
+	relayed := 0
+	for {
+		pending, err := r.outboxRepository.FindPendingEvents(relayBatchSize)
+		if err != nil {
+			return relayed, err
+		}
+		if len(pending) == 0 {
+			return relayed, nil
+		}
+
+		eventIDs := make([]string, 0, len(pending))
+		failed := false
+		for _, stored := range pending {
+			var event Event
+			if err := json.Unmarshal(stored.Payload, &event); err != nil {
+				slog.Error("Skipping undecodable outbox event", "event_id", stored.ID, "error", err)
+				eventIDs = append(eventIDs, stored.ID)
+				continue
+			}
+			if err := r.eventBus.Publish(event); err != nil {
+				slog.Warn("Outbox event will be retried", "event_id", stored.ID, "error", err)
+				failed = true
+				continue
+			}
+			relayed++
+			eventIDs = append(eventIDs, stored.ID)
+		}
+
+		if len(eventIDs) > 0 {
+			if err := r.outboxRepository.MarkEventsPublished(eventIDs); err != nil {
+				return relayed, err
+			}
+		}
+		if failed || len(pending) < relayBatchSize {
+			return relayed, nil
+		}
+	}
+}
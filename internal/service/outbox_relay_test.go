@@ -0,0 +1,124 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/config"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository/inmemory"
+)
+
+func TestOutboxRelayPublishesPendingEventsOnce(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepository()
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+	}})
+	userRepo.AddUser(&api.User{UserId: "u1", Username: "Alice", IsActive: true, TeamName: "backend"})
+
+	if err := service.CreatePR(&api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1"}, CreatePROptions{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := service.CreatePR(&api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1"}, CreatePROptions{}); err == nil {
+		t.Fatal("Expected duplicate PR to fail")
+	}
+	if _, err := service.MergePR("pr-1", false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var published []Event
+	eventBus := NewEventBus()
+	eventBus.Subscribe(func(event Event) error {
+		published = append(published, event)
+		return nil
+	})
+	relay := NewOutboxRelay(prRepo, eventBus, 0)
+
+	relayed, err := relay.RelayPending()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if relayed != 2 || len(published) != 2 {
+		t.Fatalf("Expected 2 events (the failed create writes none), got %d", len(published))
+	}
+	if published[0].Type != EventPullRequestCreated || published[1].Type != EventPullRequestMerged {
+		t.Errorf("Expected created then merged, got %s, %s", published[0].Type, published[1].Type)
+	}
+
+	relayed, _ = relay.RelayPending()
+	if relayed != 0 {
+		t.Errorf("Expected published events to be marked, relayed %d again", relayed)
+	}
+}
+
+func TestOutboxRelayKeepsEventsUntilEverySubscriberAcceptsThem(t *testing.T) {
+	healthy := &notificationReceiver{statuses: []int{http.StatusOK}}
+	healthyServer := httptest.NewServer(healthy)
+	defer healthyServer.Close()
+	failing := &notificationReceiver{statuses: []int{
+		http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK,
+	}}
+	failingServer := httptest.NewServer(failing)
+	defer failingServer.Close()
+
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepository()
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+	}})
+	userRepo.AddUser(&api.User{UserId: "u1", Username: "Alice", IsActive: true, TeamName: "backend"})
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+	if err := service.CreatePR(&api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1"}, CreatePROptions{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	deliveryRepo := inmemory.NewNotificationDeliveryRepository()
+	// startRelay wires a fresh dispatcher and relay to the stored outbox and
+	// delivery log, the way a restarted process does.
+	startRelay := func() *OutboxRelay {
+		dispatcher := NewNotificationDispatcher(config.NotificationsConfig{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			Subscribers: []config.NotificationSubscriberConfig{
+				{URL: healthyServer.URL},
+				{URL: failingServer.URL},
+			},
+		}, deliveryRepo)
+		eventBus := NewEventBus()
+		eventBus.Subscribe(dispatcher.Dispatch)
+		return NewOutboxRelay(prRepo, eventBus, 0)
+	}
+
+	relayed, err := startRelay().RelayPending()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if relayed != 0 || len(failing.received) != 3 || len(healthy.received) != 1 {
+		t.Fatalf("Expected the event to stay pending after 3 failed attempts, relayed %d, got %d and %d requests",
+			relayed, len(failing.received), len(healthy.received))
+	}
+
+	relay := startRelay()
+	relayed, err = relay.RelayPending()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if relayed != 1 || len(failing.received) != 4 {
+		t.Fatalf("Expected the restarted relay to deliver the event, relayed %d, got %d requests", relayed, len(failing.received))
+	}
+	if len(healthy.received) != 1 {
+		t.Errorf("Expected the subscriber that accepted the event not to get it again, got %d requests", len(healthy.received))
+	}
+	if relayed, _ = relay.RelayPending(); relayed != 0 {
+		t.Errorf("Expected the delivered event to be marked, relayed %d again", relayed)
+	}
+}
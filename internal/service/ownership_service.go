@@ -0,0 +1,64 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
+)
+
+type OwnershipService struct {
+	ownershipRepository repository.OwnershipRepository
+}
+
+func NewOwnershipService(ownershipRepository repository.OwnershipRepository) *OwnershipService {
+	return &OwnershipService{
+		ownershipRepository: ownershipRepository,
+	}
+}
+
+// SetRules replaces the rules of a repository, either parsed from a
+// CODEOWNERS file or given as a list.
+func (s *OwnershipService) SetRules(repositoryName string, codeowners *string, rules *[]api.OwnershipRule) (*api.OwnershipRuleSet, error) {
+	if repositoryName == "" {
+		return nil, domain.ErrRepositoryRequired
+	}
+
+	ruleSet := api.OwnershipRuleSet{Repository: repositoryName}
+	switch {
+	case codeowners != nil:
+		parsed, err := ParseCodeowners(*codeowners)
+		if err != nil {
+			return nil, domain.Invalid(fmt.Sprintf("invalid ownership rules: %v", err))
+		}
+		ruleSet.Rules = parsed
+	case rules != nil:
+		for i, rule := range *rules {
+			if err := validateOwnershipRule(rule); err != nil {
+				return nil, domain.Invalid(fmt.Sprintf("invalid ownership rules: rule %d: %v", i+1, err))
+			}
+		}
+		ruleSet.Rules = *rules
+	}
+	if len(ruleSet.Rules) == 0 {
+		return nil, domain.Invalid("invalid ownership rules: no rules given")
+	}
+
+	if err := s.ownershipRepository.SaveRules(ruleSet); err != nil {
+		return nil, err
+	}
+	return &ruleSet, nil
+}
+
+func (s *OwnershipService) GetRules(repositoryName string) (*api.OwnershipRuleSet, error) {
+	rules, err := s.ownershipRepository.FindRulesByRepository(repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	return &api.OwnershipRuleSet{Repository: repositoryName, Rules: rules}, nil
+}
+
+func (s *OwnershipService) DeleteRules(repositoryName string) error {
+	return s.ownershipRepository.DeleteRules(repositoryName)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository/inmemory"
+)
+
+func TestSetAndGetOwnershipRules(t *testing.T) {
+	service := NewOwnershipService(inmemory.NewOwnershipRepository())
+
+	codeowners := "* @acme/backend\n/docs/ @u7\n"
+	ruleSet, err := service.SetRules("search-service", &codeowners, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(ruleSet.Rules) != 2 {
+		t.Errorf("Expected 2 rules, got %d", len(ruleSet.Rules))
+	}
+
+	stored, err := service.GetRules("search-service")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stored.Rules[1].Pattern != "/docs/" {
+		t.Errorf("Expected second rule /docs/, got %s", stored.Rules[1].Pattern)
+	}
+
+	invalid := []api.OwnershipRule{{Pattern: "*.go", Owners: []string{"backend"}}}
+	_, err = service.SetRules("search-service", nil, &invalid)
+	if err == nil {
+		t.Fatal("Expected error for owner without @")
+	}
+
+	if err := service.DeleteRules("search-service"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.GetRules("search-service"); err == nil {
+		t.Fatal("Expected error after deleting rules")
+	}
+}
+
+func TestCreatePRPicksReviewerFromEachOwnerGroup(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepository()
+	ownershipRepo := inmemory.NewOwnershipRepository()
+
+	requiredReviewers := 1
+	_ = teamRepo.CreateTeam(api.Team{
+		TeamName:          "backend",
+		RequiredReviewers: &requiredReviewers,
+		Members: []api.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+		},
+	})
+	_ = teamRepo.CreateTeam(api.Team{
+		TeamName: "docs",
+		Members: []api.TeamMember{
+			{UserId: "d1", Username: "Dora", IsActive: true},
+		},
+	})
+	userRepo.AddUser(&api.User{UserId: "u1", Username: "Alice", IsActive: true, TeamName: "backend"})
+	userRepo.AddUser(&api.User{UserId: "dba", Username: "Dan", IsActive: true, TeamName: "platform"})
+
+	_ = ownershipRepo.SaveRules(api.OwnershipRuleSet{
+		Repository: "search-service",
+		Rules: []api.OwnershipRule{
+			{Pattern: "*", Owners: []string{"@acme/backend"}},
+			{Pattern: "/docs/", Owners: []string{"@acme/docs"}},
+			{Pattern: "*.sql", Owners: []string{"@dba"}},
+		},
+	})
+
+	service := NewPullRequestService(prRepo, teamRepo, userRepo, WithOwnershipRepository(ownershipRepo))
+	pr := &api.PullRequest{PullRequestId: "pr-1", PullRequestName: "Search", AuthorId: "u1"}
+	err := service.CreatePR(pr, CreatePROptions{
+		Repository:   "search-service",
+		ChangedFiles: []string{"docs/search.md", "db/schema.sql", "internal/search.go"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	assigned := make(map[string]bool)
+	for _, reviewer := range pr.AssignedReviewers {
+		assigned[reviewer] = true
+	}
+	if len(pr.AssignedReviewers) != 3 || !assigned["d1"] || !assigned["dba"] || !assigned["u2"] {
+		t.Errorf("Expected reviewers d1, dba and u2, got %v", pr.AssignedReviewers)
+	}
+}
@@ -1,148 +1,1419 @@
 package service
 
 import (
-	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"math/big"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/config"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
 )
 
+const (
+	defaultRequiredReviewers = 2
+	maxUpdateAttempts        = 5
+	defaultPageSize          = 20
+	defaultHistoryWindow     = 30 * 24 * time.Hour
+)
+
 type PullRequestService struct {
 	pullRequestRepository repository.PullRequestRepository
 	teamRepository        repository.TeamRepository
 	userRepository        repository.UserRepository
+	ownershipRepository   repository.OwnershipRepository
+	selectors             map[api.TeamSelectionStrategy]ReviewerSelector
+	defaultStrategy       api.TeamSelectionStrategy
+	teamStrategies        map[string]api.TeamSelectionStrategy
+	requiredApprovals     int
+	requireExpert         bool
+	historyWindow         time.Duration
+	maxOpenReviews        int
+	whenFull              string
+	clock                 Clock
+}
+
+// Policies for review slots that only reviewers at capacity could fill.
+const (
+	whenFullOverflow = "overflow"
+	whenFullQueue    = "queue"
+)
+
+// capacityOutcome tells how fillOverCapacity handled the open slots.
+type capacityOutcome int
+
+const (
+	capacityOK capacityOutcome = iota
+	capacityOverflow
+	capacityQueued
+)
+
+type PullRequestServiceOption func(*PullRequestService)
+
+// CreatePROptions carries request data that is used for reviewer selection
+// but is not stored on the pull request. Candidates with a tag among Labels
+// are preferred. A Draft PR is stored without reviewers until ReadyForReview.
+type CreatePROptions struct {
+	Repository   string
+	ChangedFiles []string
+	Labels       []string
+	Draft        bool
+}
+
+// WithSelectionConfig sets the default reviewer selection strategy and the
+// per-team overrides from the config file, whether a labelled PR must get a
+// matching expert and how far back history_aware looks. Unknown strategies
+// are ignored.
+func WithSelectionConfig(cfg config.SelectionConfig) PullRequestServiceOption {
+	return func(s *PullRequestService) {
+		s.requireExpert = cfg.RequireExpert
+		if cfg.HistoryWindow > 0 {
+			s.historyWindow = cfg.HistoryWindow
+		}
+		if strategy := api.TeamSelectionStrategy(cfg.DefaultStrategy); validSelectionStrategy(strategy) {
+			s.defaultStrategy = strategy
+		}
+		for teamName, value := range cfg.Teams {
+			if strategy := api.TeamSelectionStrategy(value); validSelectionStrategy(strategy) {
+				s.teamStrategies[teamName] = strategy
+			}
+		}
+	}
+}
+
+// WithReviewsConfig sets how many approvals MergePR requires. Zero, the
+// default, merges without approvals.
+func WithReviewsConfig(cfg config.ReviewsConfig) PullRequestServiceOption {
+	return func(s *PullRequestService) {
+		if cfg.RequiredApprovals > 0 {
+			s.requiredApprovals = cfg.RequiredApprovals
+		}
+	}
+}
+
+// WithCapacityConfig sets the default max_open_reviews of a reviewer, zero
+// meaning no limit, and whether PRs overflow or queue when every candidate is
+// at capacity. Overflow is the default.
+func WithCapacityConfig(cfg config.CapacityConfig) PullRequestServiceOption {
+	return func(s *PullRequestService) {
+		if cfg.MaxOpenReviews > 0 {
+			s.maxOpenReviews = cfg.MaxOpenReviews
+		}
+		if cfg.WhenFull == whenFullQueue {
+			s.whenFull = whenFullQueue
+		}
+	}
+}
+
+// WithClock replaces the system clock used for PR and assignment
+// timestamps.
+func WithClock(clock Clock) PullRequestServiceOption {
+	return func(s *PullRequestService) {
+		s.clock = clock
+	}
+}
+
+// WithReviewerSelector replaces the selector used for the given strategy.
+func WithReviewerSelector(strategy api.TeamSelectionStrategy, selector ReviewerSelector) PullRequestServiceOption {
+	return func(s *PullRequestService) {
+		s.selectors[strategy] = selector
+	}
+}
+
+// WithOwnershipRepository enables CODEOWNERS-style routing on CreatePR.
+func WithOwnershipRepository(ownershipRepository repository.OwnershipRepository) PullRequestServiceOption {
+	return func(s *PullRequestService) {
+		s.ownershipRepository = ownershipRepository
+	}
 }
 
 func NewPullRequestService(
 	pullRequestRepository repository.PullRequestRepository,
 	teamRepository repository.TeamRepository,
 	userRepository repository.UserRepository,
+	opts ...PullRequestServiceOption,
 ) *PullRequestService {
-	return &PullRequestService{
+	s := &PullRequestService{
 		pullRequestRepository: pullRequestRepository,
 		teamRepository:        teamRepository,
 		userRepository:        userRepository,
+		selectors: map[api.TeamSelectionStrategy]ReviewerSelector{
+			api.TeamSelectionStrategyRandom:      NewRandomSelector(),
+			api.TeamSelectionStrategyRoundRobin:  NewRoundRobinSelector(),
+			api.TeamSelectionStrategyLeastLoaded: NewLeastLoadedSelector(pullRequestRepository),
+		},
+		defaultStrategy: api.TeamSelectionStrategyRandom,
+		teamStrategies:  make(map[string]api.TeamSelectionStrategy),
+		whenFull:        whenFullOverflow,
+		historyWindow:   defaultHistoryWindow,
+		clock:           systemClock{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	// history_aware depends on the configured window and clock.
+	if _, ok := s.selectors[api.TeamSelectionStrategyHistoryAware]; !ok {
+		s.selectors[api.TeamSelectionStrategyHistoryAware] = NewHistoryAwareSelector(pullRequestRepository, s.historyWindow, s.clock)
+	}
+	return s
+}
+
+// selectorForTeam resolves the team's strategy: the value stored on the team
+// wins over the config override, which wins over the default.
+func (s *PullRequestService) selectorForTeam(teamName string) ReviewerSelector {
+	strategy := s.defaultStrategy
+	if configured, ok := s.teamStrategies[teamName]; ok {
+		strategy = configured
+	}
+	if team := s.teamRepository.FindTeamByName(teamName); team.SelectionStrategy != nil {
+		strategy = *team.SelectionStrategy
+	}
+
+	if selector, ok := s.selectors[strategy]; ok {
+		return selector
+	}
+	return s.selectors[api.TeamSelectionStrategyRandom]
+}
+
+func (s *PullRequestService) requiredReviewers(teamName string) int {
+	team := s.teamRepository.FindTeamByName(teamName)
+	if team.RequiredReviewers != nil {
+		return *team.RequiredReviewers
+	}
+	return defaultRequiredReviewers
+}
+
+// selectReviewers picks count reviewers for a PR of authorID in teamName
+// from the candidates that are below their open review limit.
+func (s *PullRequestService) selectReviewers(teamName, authorID string, candidates []api.TeamMember, count int) ([]string, error) {
+	if count <= 0 || len(candidates) == 0 {
+		return []string{}, nil
+	}
+	candidates, err := s.underCapacity(teamName, candidates)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return []string{}, nil
+	}
+	return s.selectorForTeam(teamName).SelectReviewers(SelectionRequest{
+		TeamName:   teamName,
+		AuthorID:   authorID,
+		Candidates: candidates,
+		Count:      count,
+	})
+}
+
+// underCapacity drops the candidates that already have as many open reviews
+// as their limit: their own max_open_reviews, else the one of teamName, else
+// the configured default.
+func (s *PullRequestService) underCapacity(teamName string, candidates []api.TeamMember) ([]api.TeamMember, error) {
+	teamLimit := s.maxOpenReviews
+	if team := s.teamRepository.FindTeamByName(teamName); team.MaxOpenReviews != nil {
+		teamLimit = *team.MaxOpenReviews
+	}
+
+	limits := make(map[string]int, len(candidates))
+	var limited []string
+	for _, candidate := range candidates {
+		limit := teamLimit
+		user, err := s.userRepository.FindUserByID(candidate.UserId)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+		if user != nil && user.MaxOpenReviews != nil {
+			limit = *user.MaxOpenReviews
+		}
+		if limit > 0 {
+			limits[candidate.UserId] = limit
+			limited = append(limited, candidate.UserId)
+		}
+	}
+	if len(limited) == 0 {
+		return candidates, nil
+	}
+
+	load, err := s.pullRequestRepository.CountOpenReviewsByUsers(limited)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count open reviews: %w", err)
+	}
+	var available []api.TeamMember
+	for _, candidate := range candidates {
+		if limit, ok := limits[candidate.UserId]; ok && load[candidate.UserId] >= limit {
+			continue
+		}
+		available = append(available, candidate)
+	}
+	return available, nil
+}
+
+// fillOverCapacity handles the slots up to required that selection left
+// open on pr. When active members of teamName or its fallback teams outside
+// excluded were skipped only for being at capacity, the overflow policy
+// assigns them anyway and lists them in OverflowReviewers, and the queue
+// policy marks pr as queued until capacity frees up.
+func (s *PullRequestService) fillOverCapacity(pr *api.PullRequest, teamName string, required int, excluded map[string]bool) (capacityOutcome, error) {
+	count := required - len(pr.AssignedReviewers)
+	if count <= 0 || teamName == "" {
+		return capacityOK, nil
+	}
+
+	skip := map[string]bool{pr.AuthorId: true}
+	for userID := range excluded {
+		skip[userID] = true
+	}
+	for _, reviewer := range pr.AssignedReviewers {
+		skip[reviewer] = true
+	}
+	teams := []string{teamName}
+	if team := s.teamRepository.FindTeamByName(teamName); team.FallbackTeams != nil {
+		teams = append(teams, *team.FallbackTeams...)
+	}
+	var atCapacity []api.TeamMember
+	for _, name := range teams {
+		members, err := s.activeTeamMembers(name, "")
+		if err != nil {
+			return capacityOK, err
+		}
+		for _, member := range members {
+			if !skip[member.UserId] {
+				skip[member.UserId] = true
+				atCapacity = append(atCapacity, member)
+			}
+		}
+	}
+	if len(atCapacity) == 0 {
+		return capacityOK, nil
+	}
+
+	if s.whenFull == whenFullQueue {
+		pr.IsQueued = true
+		return capacityQueued, nil
+	}
+	selected, err := s.selectorForTeam(teamName).SelectReviewers(SelectionRequest{
+		TeamName:   teamName,
+		AuthorID:   pr.AuthorId,
+		Candidates: atCapacity,
+		Count:      count,
+	})
+	if err != nil {
+		return capacityOK, err
+	}
+	pr.AssignedReviewers = append(pr.AssignedReviewers, selected...)
+	addOverflowReviewers(pr, selected)
+	return capacityOverflow, nil
+}
+
+func (s *PullRequestService) GetActiveTeamMembers(authorID string) ([]api.TeamMember, error) {
+	author, err := s.findAuthor(authorID)
+	if err != nil {
+		return nil, err
+	}
+	return s.activeTeamMembers(author.TeamName, authorID)
+}
+
+func (s *PullRequestService) findAuthor(authorID string) (*api.User, error) {
+	author, err := s.userRepository.FindUserByID(authorID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrAuthorNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(author.Teams) == 0 {
+		return nil, domain.ErrAuthorHasNoTeam
+	}
+	return author, nil
+}
+
+// prTeam resolves the team a new PR is opened for: the requested team, which
+// the author must belong to, or the author's first team.
+func prTeam(author *api.User, requested string) (string, error) {
+	if requested == "" {
+		return author.Teams[0], nil
+	}
+	if !memberOf(author, requested) {
+		return "", domain.ErrAuthorNotTeamMember
+	}
+	return requested, nil
+}
+
+func memberOf(user *api.User, teamName string) bool {
+	for _, team := range user.Teams {
+		if team == teamName {
+			return true
+		}
+	}
+	return false
+}
+
+// replacementTeam is the team a replacement for reviewer is taken from: the
+// PR's team when the reviewer belongs to it, otherwise the reviewer's first
+// team, which is where owners and fallback reviewers come from.
+func replacementTeam(pr *api.PullRequest, reviewer *api.User) string {
+	if memberOf(reviewer, pr.TeamName) {
+		return pr.TeamName
+	}
+	return reviewer.TeamName
+}
+
+// activeTeamMembers lists the active members of teamName other than
+// excludeID, leaving out those that are away. Archived teams have no
+// candidates.
+func (s *PullRequestService) activeTeamMembers(teamName string, excludeID string) ([]api.TeamMember, error) {
+	members, err := s.teamRepository.FindTeamMembersByName(teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+	if s.teamRepository.FindTeamByName(teamName).IsArchived {
+		return nil, nil
+	}
+	away, err := s.awayUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	var activeMembers []api.TeamMember
+	for _, member := range members {
+		if member.IsActive && !away[member.UserId] && member.UserId != excludeID {
+			activeMembers = append(activeMembers, member)
+		}
+	}
+
+	return activeMembers, nil
+}
+
+// awayUsers returns the ids of the users inside an away period right now.
+func (s *PullRequestService) awayUsers() (map[string]bool, error) {
+	periods, err := s.userRepository.FindAwayPeriodsAt(s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get away periods: %w", err)
+	}
+	away := make(map[string]bool, len(periods))
+	for _, period := range periods {
+		away[period.UserId] = true
+	}
+	return away, nil
+}
+
+func (s *PullRequestService) SelectRandomReviewers(members []api.TeamMember, count int) []string {
+	reviewers, err := NewRandomSelector().SelectReviewers(SelectionRequest{Candidates: members, Count: count})
+	if err != nil {
+		return []string{}
+	}
+	return reviewers
+}
+
+func (s *PullRequestService) CreatePR(pr *api.PullRequest, opts CreatePROptions) error {
+	author, err := s.findAuthor(pr.AuthorId)
+	if err != nil {
+		return err
+	}
+	teamName, err := prTeam(author, pr.TeamName)
+	if err != nil {
+		return err
+	}
+	if s.teamRepository.FindTeamByName(teamName).IsArchived {
+		return domain.ErrTeamArchived
+	}
+
+	pr.TeamName = teamName
+	pr.AssignedReviewers = []string{}
+	pr.IsDraft = opts.Draft
+	if !opts.Draft {
+		reviewers, fallback, err := s.pickInitialReviewers(author, teamName, opts)
+		if err != nil {
+			return err
+		}
+		pr.AssignedReviewers = reviewers
+		addFallbackReviewers(pr, fallback)
+		if _, err := s.fillOverCapacity(pr, teamName, s.requiredReviewers(teamName), nil); err != nil {
+			return err
+		}
+	}
+	pr.Status = api.PullRequestStatusOPEN
+	now := s.clock.Now()
+	pr.CreatedAt = &now
+
+	event, err := newOutboxEvent(EventPullRequestCreated, now, *pr, pr.AssignedReviewers, nil)
+	if err != nil {
+		return err
+	}
+	records := s.assignmentRecords(*pr, api.ReviewerAssignmentActionCreate, pr.AssignedReviewers, "")
+	return s.insertPR(*pr, append(records, event)...)
+}
+
+// insertPR and savePR store pr stamped with the service clock, so that the
+// history the repository writes on its own uses the same time. savePR bumps
+// pr.Version like the repository does, so callers return the stored version.
+func (s *PullRequestService) insertPR(pr api.PullRequest, records ...repository.Record) error {
+	return s.pullRequestRepository.CreatePR(pr, append(records, repository.ChangeTime(s.clock.Now()))...)
+}
+
+func (s *PullRequestService) savePR(pr *api.PullRequest, records ...repository.Record) error {
+	if err := s.pullRequestRepository.UpdatePR(*pr, append(records, repository.ChangeTime(s.clock.Now()))...); err != nil {
+		return err
+	}
+	pr.Version++
+	return nil
+}
+
+// assignmentRecords builds history entries for reviewers added to pr,
+// replacedID is empty when nobody was replaced.
+func (s *PullRequestService) assignmentRecords(pr api.PullRequest, action api.ReviewerAssignmentAction, reviewers []string, replacedID string) []repository.Record {
+	now := s.clock.Now()
+	records := make([]repository.Record, 0, len(reviewers)+1)
+	for _, reviewer := range reviewers {
+		assignment := repository.AssignmentRecord{
+			PullRequestId: pr.PullRequestId,
+			UserId:        reviewer,
+			Action:        action,
+			AssignedAt:    now,
+		}
+		if replacedID != "" {
+			replaced := replacedID
+			assignment.ReplacedUserId = &replaced
+		}
+		records = append(records, assignment)
+	}
+	return records
+}
+
+// GetHistory returns every reviewer assignment of a PR in the order they
+// were made.
+func (s *PullRequestService) GetHistory(prID string) ([]api.ReviewerAssignment, error) {
+	if _, err := s.pullRequestRepository.FindPRByID(prID); err != nil {
+		return nil, err
+	}
+	return s.pullRequestRepository.FindAssignmentsByPR(prID)
+}
+
+// GetPRDetails returns a PR with its current reviewers, each with their user
+// details, the assignment that put them on the PR and their latest decision. A reviewer whose user
+// no longer exists is reported by user_id only.
+func (s *PullRequestService) GetPRDetails(prID string) (*api.PullRequest, []api.ReviewerDetails, error) {
+	pr, err := s.pullRequestRepository.FindPRByID(prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	assignments, err := s.pullRequestRepository.FindAssignmentsByPR(prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	current := make(map[string]api.ReviewerAssignment, len(pr.AssignedReviewers))
+	for _, assignment := range assignments {
+		if assignment.UnassignedAt == nil {
+			current[assignment.UserId] = assignment
+		}
+	}
+	decisions, err := s.latestDecisions(prID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reviewers := make([]api.ReviewerDetails, 0, len(pr.AssignedReviewers))
+	for _, reviewerID := range pr.AssignedReviewers {
+		assignment := current[reviewerID]
+		details := api.ReviewerDetails{
+			UserId:         reviewerID,
+			Action:         assignment.Action,
+			AssignedAt:     assignment.AssignedAt,
+			ReplacedUserId: assignment.ReplacedUserId,
+		}
+		if review, ok := decisions[reviewerID]; ok {
+			details.Decision = &review.Decision
+			details.DecidedAt = &review.SubmittedAt
+		}
+		user, err := s.userRepository.FindUserByID(reviewerID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, err
+		}
+		if user != nil {
+			details.Username = user.Username
+			details.IsActive = user.IsActive
+			details.TeamName = replacementTeam(pr, user)
+		}
+		reviewers = append(reviewers, details)
+	}
+	return pr, reviewers, nil
+}
+
+// pickInitialReviewers takes one reviewer from every owner group matched by
+// the changed files, an expert for the labels when one is required and the
+// seniors the team's policy asks for, then fills the remaining slots from
+// teamName, experts first, and after that from its fallback teams. The
+// second result lists the reviewers that came from a fallback team.
+func (s *PullRequestService) pickInitialReviewers(author *api.User, teamName string, opts CreatePROptions) ([]string, []string, error) {
+	groups, err := s.ownerGroups(author.UserId, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reviewers := []string{}
+	picked := make(map[string]bool)
+	for _, group := range groups {
+		var candidates []api.TeamMember
+		covered := false
+		for _, member := range group {
+			if picked[member.UserId] {
+				covered = true
+				break
+			}
+			candidates = append(candidates, member)
+		}
+		if covered {
+			continue
+		}
+
+		selected, err := s.selectReviewers(teamName, author.UserId, candidates, 1)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, reviewer := range selected {
+			picked[reviewer] = true
+			reviewers = append(reviewers, reviewer)
+		}
+	}
+
+	labels := labelSet(opts.Labels)
+	var fallbackPicked []string
+	if s.requireExpert && len(labels) > 0 {
+		expert, fromFallback, err := s.pickExpert(teamName, author.UserId, reviewers, picked, labels)
+		if err != nil {
+			return nil, nil, err
+		}
+		if expert != "" {
+			picked[expert] = true
+			reviewers = append(reviewers, expert)
+		}
+		if fromFallback {
+			fallbackPicked = append(fallbackPicked, expert)
+		}
+	}
+
+	seniors, seniorFallback, err := s.pickSeniors(teamName, author.UserId, reviewers, picked)
+	if err != nil {
+		return nil, nil, err
+	}
+	reviewers = append(reviewers, seniors...)
+	fallbackPicked = append(fallbackPicked, seniorFallback...)
+
+	activeMembers, err := s.activeTeamMembers(teamName, author.UserId)
+	if err != nil {
+		return nil, nil, err
+	}
+	var candidates []api.TeamMember
+	for _, member := range activeMembers {
+		if !picked[member.UserId] {
+			candidates = append(candidates, member)
+		}
+	}
+	experts, others, err := s.splitExperts(candidates, labels)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requiredReviewers := s.requiredReviewers(teamName)
+	for _, group := range [][]api.TeamMember{experts, others} {
+		selected, err := s.selectReviewers(teamName, author.UserId, group, requiredReviewers-len(reviewers))
+		if err != nil {
+			return nil, nil, err
+		}
+		reviewers = append(reviewers, selected...)
+		for _, reviewer := range selected {
+			picked[reviewer] = true
+		}
+	}
+	picked[author.UserId] = true
+
+	fallback, err := s.fallbackReviewers(teamName, author.UserId, picked, requiredReviewers-len(reviewers))
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(reviewers, fallback...), append(fallbackPicked, fallback...), nil
+}
+
+// labelSet returns the labels of a PR in the form tags are stored in.
+func labelSet(labels []string) map[string]bool {
+	set := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		if label = strings.ToLower(strings.TrimSpace(label)); label != "" {
+			set[label] = true
+		}
+	}
+	return set
+}
+
+func matchesLabels(user *api.User, labels map[string]bool) bool {
+	if user == nil || user.Tags == nil {
+		return false
+	}
+	for _, tag := range *user.Tags {
+		if labels[tag] {
+			return true
+		}
+	}
+	return false
+}
+
+// splitExperts separates the candidates with a tag among labels from the
+// others, keeping their order.
+func (s *PullRequestService) splitExperts(candidates []api.TeamMember, labels map[string]bool) ([]api.TeamMember, []api.TeamMember, error) {
+	if len(labels) == 0 {
+		return nil, candidates, nil
+	}
+	var experts, others []api.TeamMember
+	for _, candidate := range candidates {
+		user, err := s.userRepository.FindUserByID(candidate.UserId)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, err
+		}
+		if matchesLabels(user, labels) {
+			experts = append(experts, candidate)
+		} else {
+			others = append(others, candidate)
+		}
+	}
+	return experts, others, nil
+}
+
+// pickExpert returns an expert for labels unless one of reviewers already is:
+// an active member of teamName when it has one below capacity, otherwise one
+// of its fallback teams, selected under that team. The second result tells
+// whether the expert came from a fallback team. It returns "" when nobody
+// matches.
+func (s *PullRequestService) pickExpert(
+	teamName string,
+	authorID string,
+	reviewers []string,
+	picked map[string]bool,
+	labels map[string]bool,
+) (string, bool, error) {
+	for _, reviewerID := range reviewers {
+		reviewer, err := s.userRepository.FindUserByID(reviewerID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return "", false, err
+		}
+		if matchesLabels(reviewer, labels) {
+			return "", false, nil
+		}
+	}
+
+	members, err := s.activeTeamMembers(teamName, authorID)
+	if err != nil {
+		return "", false, err
+	}
+	var candidates []api.TeamMember
+	for _, member := range members {
+		if !picked[member.UserId] {
+			candidates = append(candidates, member)
+		}
+	}
+	experts, _, err := s.splitExperts(candidates, labels)
+	if err != nil {
+		return "", false, err
+	}
+	selected, err := s.selectReviewers(teamName, authorID, experts, 1)
+	if err != nil || len(selected) > 0 {
+		return firstOrEmpty(selected), false, err
+	}
+
+	excluded := map[string]bool{authorID: true}
+	for userID := range picked {
+		excluded[userID] = true
+	}
+	excluded, err = s.expertOnly(teamName, labels, excluded)
+	if err != nil {
+		return "", false, err
+	}
+	fallback, err := s.fallbackReviewers(teamName, authorID, excluded, 1)
+	return firstOrEmpty(fallback), len(fallback) > 0, err
+}
+
+// expertOnly returns a copy of excluded that also holds every active member
+// of the fallback teams of teamName without a tag among labels, so that
+// selection from them only yields experts.
+func (s *PullRequestService) expertOnly(teamName string, labels map[string]bool, excluded map[string]bool) (map[string]bool, error) {
+	result := make(map[string]bool, len(excluded))
+	for userID := range excluded {
+		result[userID] = true
+	}
+	team := s.teamRepository.FindTeamByName(teamName)
+	if team.FallbackTeams == nil {
+		return result, nil
+	}
+	for _, name := range *team.FallbackTeams {
+		members, err := s.activeTeamMembers(name, "")
+		if err != nil {
+			return nil, err
+		}
+		_, others, err := s.splitExperts(members, labels)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range others {
+			result[member.UserId] = true
+		}
+	}
+	return result, nil
+}
+
+func firstOrEmpty(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// fallbackReviewers fills up to count slots of a PR of authorID from the
+// fallback teams of teamName, in the order they are declared. Selected users
+// are added to excluded.
+func (s *PullRequestService) fallbackReviewers(teamName, authorID string, excluded map[string]bool, count int) ([]string, error) {
+	team := s.teamRepository.FindTeamByName(teamName)
+	if team.FallbackTeams == nil {
+		return nil, nil
+	}
+
+	var reviewers []string
+	for _, fallbackTeam := range *team.FallbackTeams {
+		if len(reviewers) >= count {
+			break
+		}
+
+		members, err := s.activeTeamMembers(fallbackTeam, "")
+		if err != nil {
+			continue
+		}
+		var candidates []api.TeamMember
+		for _, member := range members {
+			if !excluded[member.UserId] {
+				candidates = append(candidates, member)
+			}
+		}
+
+		selected, err := s.selectReviewers(fallbackTeam, authorID, candidates, count-len(reviewers))
+		if err != nil {
+			return nil, err
+		}
+		for _, reviewer := range selected {
+			excluded[reviewer] = true
+			reviewers = append(reviewers, reviewer)
+		}
+	}
+	return reviewers, nil
+}
+
+func addFallbackReviewers(pr *api.PullRequest, reviewers []string) {
+	if len(reviewers) == 0 {
+		return
+	}
+	var fallback []string
+	if pr.FallbackReviewers != nil {
+		fallback = append(fallback, *pr.FallbackReviewers...)
+	}
+	fallback = append(fallback, reviewers...)
+	pr.FallbackReviewers = &fallback
+}
+
+func addOverflowReviewers(pr *api.PullRequest, reviewers []string) {
+	if len(reviewers) == 0 {
+		return
+	}
+	var overflow []string
+	if pr.OverflowReviewers != nil {
+		overflow = append(overflow, *pr.OverflowReviewers...)
+	}
+	overflow = append(overflow, reviewers...)
+	pr.OverflowReviewers = &overflow
+}
+
+// removeReviewer drops a reviewer from the assigned reviewers and from the
+// fallback and overflow reviewers of pr.
+func removeReviewer(pr *api.PullRequest, reviewerID string) {
+	reviewers := []string{}
+	for _, reviewer := range pr.AssignedReviewers {
+		if reviewer != reviewerID {
+			reviewers = append(reviewers, reviewer)
+		}
+	}
+	pr.AssignedReviewers = reviewers
+
+	if pr.FallbackReviewers == nil {
+		return
+	}
+	var fallback []string
+	for _, reviewer := range *pr.FallbackReviewers {
+		if reviewer != reviewerID {
+			fallback = append(fallback, reviewer)
+		}
+	}
+	pr.FallbackReviewers = nil
+	if len(fallback) > 0 {
+		pr.FallbackReviewers = &fallback
+	}
+
+	if pr.OverflowReviewers == nil {
+		return
+	}
+	var overflow []string
+	for _, reviewer := range *pr.OverflowReviewers {
+		if reviewer != reviewerID {
+			overflow = append(overflow, reviewer)
+		}
+	}
+	pr.OverflowReviewers = nil
+	if len(overflow) > 0 {
+		pr.OverflowReviewers = &overflow
+	}
+}
+
+// ownerGroups resolves the owners of every changed file. Each matching rule
+// gives one group: its listed users plus the members of its listed teams,
+// limited to active users other than the author.
+func (s *PullRequestService) ownerGroups(authorID string, opts CreatePROptions) ([][]api.TeamMember, error) {
+	if s.ownershipRepository == nil || opts.Repository == "" || len(opts.ChangedFiles) == 0 {
+		return nil, nil
+	}
+	rules, err := s.ownershipRepository.FindRulesByRepository(opts.Repository)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var groups [][]api.TeamMember
+	seen := make(map[string]bool)
+	for _, file := range opts.ChangedFiles {
+		rule := matchOwnershipRule(rules, file)
+		if rule == nil || len(rule.Owners) == 0 {
+			continue
+		}
+		key := strings.Join(rule.Owners, " ")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		group, err := s.ownerCandidates(rule.Owners, authorID)
+		if err != nil {
+			return nil, err
+		}
+		if len(group) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+func (s *PullRequestService) ownerCandidates(owners []string, authorID string) ([]api.TeamMember, error) {
+	away, err := s.awayUsers()
+	if err != nil {
+		return nil, err
+	}
+	var candidates []api.TeamMember
+	added := make(map[string]bool)
+	for _, value := range owners {
+		o, err := parseOwner(value)
+		if err != nil {
+			continue
+		}
+
+		var members []api.TeamMember
+		switch o.kind {
+		case ownerTeam:
+			if !s.teamRepository.ExistTeamByName(o.name) {
+				continue
+			}
+			members, err = s.activeTeamMembers(o.name, authorID)
+			if err != nil {
+				return nil, err
+			}
+		case ownerUser:
+			user, err := s.userRepository.FindUserByID(o.name)
+			if err != nil || !user.IsActive || away[user.UserId] || user.UserId == authorID {
+				continue
+			}
+			members = []api.TeamMember{{UserId: user.UserId, Username: user.Username, IsActive: user.IsActive}}
+		case ownerEmail:
+			// Users have no email, so email owners never match anyone.
+			continue
+		}
+
+		for _, member := range members {
+			if !added[member.UserId] {
+				added[member.UserId] = true
+				candidates = append(candidates, member)
+			}
+		}
+	}
+	return candidates, nil
+}
+
+func (s *PullRequestService) FindPRByID(prID string) (*api.PullRequest, error) {
+	return s.pullRequestRepository.FindPRByID(prID)
+}
+
+// retryOnConflict runs update again while it fails because the PR changed
+// after update read it. The last conflict is returned when attempts run out.
+func retryOnConflict(update func() error) error {
+	var err error
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		err = update()
+		if !errors.Is(err, domain.ErrVersionConflict) {
+			return err
+		}
+	}
+	return err
+}
+
+// MergePR merges an open PR once enough of its current reviewers approved
+// it. adminOverride merges without the approvals. Merging a merged PR
+// changes nothing.
+func (s *PullRequestService) MergePR(prID string, adminOverride bool) (*api.PullRequest, error) {
+	var merged *api.PullRequest
+	err := retryOnConflict(func() error {
+		var err error
+		merged, err = s.mergePR(prID, adminOverride)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+func (s *PullRequestService) mergePR(prID string, adminOverride bool) (*api.PullRequest, error) {
+	pr, err := s.pullRequestRepository.FindPRByID(prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.Status == api.PullRequestStatusCLOSED {
+		return nil, domain.ErrPRClosed
+	}
+	if pr.IsDraft {
+		return nil, domain.ErrPRDraft
+	}
+	if pr.Status != api.PullRequestStatusMERGED {
+		if !adminOverride {
+			if err := s.checkApprovals(pr); err != nil {
+				return nil, err
+			}
+		}
+		pr.Status = api.PullRequestStatusMERGED
+		now := s.clock.Now()
+		pr.MergedAt = &now
+		event, err := newOutboxEvent(EventPullRequestMerged, now, *pr, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		err = s.savePR(pr, event)
+		if err != nil {
+			return nil, err
+		}
+		s.assignQueuedPRs()
+	}
+
+	return pr, nil
+}
+
+// checkApprovals requires the configured number of approvals from the
+// current reviewers, or all of them when the PR has fewer reviewers. A PR
+// without reviewers still needs one approval, so it cannot be merged.
+func (s *PullRequestService) checkApprovals(pr *api.PullRequest) error {
+	if s.requiredApprovals == 0 {
+		return nil
+	}
+	required := max(min(s.requiredApprovals, len(pr.AssignedReviewers)), 1)
+	decisions, err := s.latestDecisions(pr.PullRequestId)
+	if err != nil {
+		return err
+	}
+	approvals := 0
+	for _, reviewer := range pr.AssignedReviewers {
+		if review, ok := decisions[reviewer]; ok && review.Decision == api.ReviewDecisionDecisionApproved {
+			approvals++
+		}
+	}
+	if approvals < required {
+		return domain.Wrap(domain.ErrNotApproved, fmt.Errorf("%d of %d approvals", approvals, required))
+	}
+	return nil
+}
+
+// latestDecisions returns the last decision of every reviewer of a PR.
+func (s *PullRequestService) latestDecisions(prID string) (map[string]api.ReviewDecision, error) {
+	reviews, err := s.pullRequestRepository.FindReviewsByPR(prID)
+	if err != nil {
+		return nil, err
+	}
+	latest := make(map[string]api.ReviewDecision, len(reviews))
+	for _, review := range reviews {
+		latest[review.UserId] = review
+	}
+	return latest, nil
+}
+
+// SubmitReview records the decision of a reviewer assigned to an open PR.
+// Only the latest decision of each reviewer counts towards merging.
+func (s *PullRequestService) SubmitReview(prID string, userID string, decision api.ReviewDecisionDecision) (*api.PullRequest, *api.ReviewDecision, error) {
+	switch decision {
+	case api.ReviewDecisionDecisionApproved, api.ReviewDecisionDecisionChangesRequested, api.ReviewDecisionDecisionCommented:
+	default:
+		return nil, nil, domain.ErrInvalidDecision
+	}
+
+	var pr *api.PullRequest
+	var review *api.ReviewDecision
+	err := retryOnConflict(func() error {
+		var err error
+		pr, review, err = s.submitReview(prID, userID, decision)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return pr, review, nil
+}
+
+func (s *PullRequestService) submitReview(prID string, userID string, decision api.ReviewDecisionDecision) (*api.PullRequest, *api.ReviewDecision, error) {
+	pr, err := s.pullRequestRepository.FindPRByID(prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch pr.Status {
+	case api.PullRequestStatusMERGED:
+		return nil, nil, domain.ErrPRAlreadyMerged
+	case api.PullRequestStatusCLOSED:
+		return nil, nil, domain.ErrPRClosed
+	}
+	if !containsReviewer(*pr, userID) {
+		return nil, nil, domain.ErrNotAssigned
+	}
+
+	review := api.ReviewDecision{
+		PullRequestId: prID,
+		UserId:        userID,
+		Decision:      decision,
+		SubmittedAt:   s.clock.Now(),
+	}
+	event, err := newOutboxEvent(EventReviewSubmitted, s.clock.Now(), *pr, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.savePR(pr, repository.ReviewRecord(review), event); err != nil {
+		return nil, nil, err
+	}
+	return pr, &review, nil
+}
+
+// ReadyForReview turns a draft into a PR under review and assigns its
+// reviewers like CreatePR does. A PR that is not a draft is returned as is.
+func (s *PullRequestService) ReadyForReview(prID string, opts CreatePROptions) (*api.PullRequest, error) {
+	var ready *api.PullRequest
+	err := retryOnConflict(func() error {
+		var err error
+		ready, err = s.readyForReview(prID, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ready, nil
+}
+
+func (s *PullRequestService) readyForReview(prID string, opts CreatePROptions) (*api.PullRequest, error) {
+	pr, err := s.pullRequestRepository.FindPRByID(prID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case pr.Status == api.PullRequestStatusMERGED:
+		return nil, domain.ErrPRAlreadyMerged
+	case pr.Status == api.PullRequestStatusCLOSED:
+		return nil, domain.ErrPRClosed
+	case !pr.IsDraft:
+		return pr, nil
+	}
+
+	author, err := s.findAuthor(pr.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+	if s.teamRepository.FindTeamByName(pr.TeamName).IsArchived {
+		return nil, domain.ErrTeamArchived
+	}
+	reviewers, fallback, err := s.pickInitialReviewers(author, pr.TeamName, opts)
+	if err != nil {
+		return nil, err
+	}
+	pr.IsDraft = false
+	pr.AssignedReviewers = reviewers
+	addFallbackReviewers(pr, fallback)
+	if _, err := s.fillOverCapacity(pr, pr.TeamName, s.requiredReviewers(pr.TeamName), nil); err != nil {
+		return nil, err
+	}
+
+	event, err := newOutboxEvent(EventPullRequestReady, s.clock.Now(), *pr, pr.AssignedReviewers, nil)
+	if err != nil {
+		return nil, err
+	}
+	records := s.assignmentRecords(*pr, api.ReviewerAssignmentActionCreate, pr.AssignedReviewers, "")
+	if err := s.savePR(pr, append(records, event)...); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// ClosePR closes an open PR without merging it. Its reviewers stay assigned
+// but no longer count as open reviews. Closing a closed PR changes nothing.
+func (s *PullRequestService) ClosePR(prID string) (*api.PullRequest, error) {
+	var closed *api.PullRequest
+	err := retryOnConflict(func() error {
+		var err error
+		closed, err = s.closePR(prID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return closed, nil
+}
+
+func (s *PullRequestService) closePR(prID string) (*api.PullRequest, error) {
+	pr, err := s.pullRequestRepository.FindPRByID(prID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch pr.Status {
+	case api.PullRequestStatusMERGED:
+		return nil, domain.ErrPRAlreadyMerged
+	case api.PullRequestStatusCLOSED:
+		return pr, nil
+	}
+
+	pr.Status = api.PullRequestStatusCLOSED
+	now := s.clock.Now()
+	pr.ClosedAt = &now
+	event, err := newOutboxEvent(EventPullRequestClosed, now, *pr, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.savePR(pr, event); err != nil {
+		return nil, err
+	}
+	s.assignQueuedPRs()
+	return pr, nil
+}
+
+// assignQueuedPRs tops up the queued open PRs now that a review stopped
+// counting towards its reviewers' capacity. A PR that fails is logged and
+// stays queued for the next review to finish.
+func (s *PullRequestService) assignQueuedPRs() {
+	prs, err := s.pullRequestRepository.ListPRs(repository.PRFilter{
+		Status:     api.PullRequestStatusOPEN,
+		QueuedOnly: true,
+	})
+	if err != nil {
+		slog.Warn("Failed to list queued PRs", "error", err)
+		return
+	}
+	for _, pr := range prs {
+		err := retryOnConflict(func() error {
+			return s.assignQueuedPR(pr.PullRequestId)
+		})
+		if err != nil {
+			slog.Warn("Failed to assign queued PR", "pull_request_id", pr.PullRequestId, "error", err)
+		}
+	}
+}
+
+func (s *PullRequestService) assignQueuedPR(prID string) error {
+	pr, err := s.pullRequestRepository.FindPRByID(prID)
+	if err != nil {
+		return err
+	}
+	if !pr.IsQueued || pr.Status != api.PullRequestStatusOPEN {
+		return nil
+	}
+
+	excluded := map[string]bool{pr.AuthorId: true}
+	for _, reviewer := range pr.AssignedReviewers {
+		excluded[reviewer] = true
+	}
+	kept := len(pr.AssignedReviewers)
+	pr.IsQueued = false
+	if err := s.topUpReviewers(pr, excluded); err != nil {
+		return err
+	}
+	added := pr.AssignedReviewers[kept:]
+	if len(added) == 0 && pr.IsQueued {
+		return nil
 	}
-}
 
-func randomIndex(n int) (int, error) {
-	maxN := big.NewInt(int64(n))
-	num, err := rand.Int(rand.Reader, maxN)
+	event, err := newOutboxEvent(EventReviewersChanged, s.clock.Now(), *pr, added, nil)
 	if err != nil {
-		return 0, err
+		return err
 	}
-	return int(num.Int64()), nil
+	records := s.assignmentRecords(*pr, api.ReviewerAssignmentActionQueue, added, "")
+	return s.savePR(pr, append(records, event)...)
 }
 
-func (s *PullRequestService) GetActiveTeamMembers(authorID string) ([]api.TeamMember, error) {
-	author, err := s.userRepository.FindUserByID(authorID)
+// ReopenPR opens a closed PR again. Reviewers that were deactivated or
+// removed meanwhile are dropped and the PR is topped up to its team's
+// required_reviewers, then from the fallback teams; a draft stays without
+// reviewers. Reopening an open PR changes nothing.
+func (s *PullRequestService) ReopenPR(prID string) (*api.PullRequest, error) {
+	var reopened *api.PullRequest
+	err := retryOnConflict(func() error {
+		var err error
+		reopened, err = s.reopenPR(prID)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("author not found")
+		return nil, err
 	}
+	return reopened, nil
+}
 
-	if author.TeamName == "" {
-		return nil, fmt.Errorf("author has no team")
+func (s *PullRequestService) reopenPR(prID string) (*api.PullRequest, error) {
+	pr, err := s.pullRequestRepository.FindPRByID(prID)
+	if err != nil {
+		return nil, err
 	}
 
-	members, err := s.teamRepository.FindTeamMembersByName(author.TeamName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get team members: %w", err)
+	switch pr.Status {
+	case api.PullRequestStatusMERGED:
+		return nil, domain.ErrPRAlreadyMerged
+	case api.PullRequestStatusOPEN:
+		return pr, nil
 	}
 
-	var activeMembers []api.TeamMember
-	for _, member := range members {
-		if member.IsActive && member.UserId != authorID {
-			activeMembers = append(activeMembers, member)
+	var removed []string
+	excluded := map[string]bool{pr.AuthorId: true}
+	for _, reviewerID := range append([]string{}, pr.AssignedReviewers...) {
+		reviewer, err := s.userRepository.FindUserByID(reviewerID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, err
 		}
+		if reviewer == nil || !reviewer.IsActive {
+			removeReviewer(pr, reviewerID)
+			removed = append(removed, reviewerID)
+		}
+		excluded[reviewerID] = true
 	}
 
-	return activeMembers, nil
-}
+	kept := len(pr.AssignedReviewers)
+	if pr.TeamName != "" && !pr.IsDraft {
+		if err := s.topUpReviewers(pr, excluded); err != nil {
+			return nil, err
+		}
+	}
+	added := pr.AssignedReviewers[kept:]
 
-func (s *PullRequestService) SelectRandomReviewers(members []api.TeamMember, count int) []string {
-	if count > len(members) {
-		count = len(members)
+	pr.Status = api.PullRequestStatusOPEN
+	pr.ClosedAt = nil
+	event, err := newOutboxEvent(EventPullRequestReopened, s.clock.Now(), *pr, added, removed)
+	if err != nil {
+		return nil, err
 	}
-	if count > 2 {
-		count = 2
+	records := []repository.Record{event}
+	for i, reviewer := range added {
+		replaced := ""
+		if i < len(removed) {
+			replaced = removed[i]
+		}
+		records = append(records, s.assignmentRecords(*pr, api.ReviewerAssignmentActionReopen, []string{reviewer}, replaced)...)
 	}
-
-	if count == 0 {
-		return []string{}
+	if err := s.savePR(pr, records...); err != nil {
+		return nil, err
 	}
+	return pr, nil
+}
 
-	indexes := make([]int, len(members))
-	for i := 0; i < len(members); i++ {
-		indexes[i] = i
+// topUpReviewers adds active members of the PR's team, then of its fallback
+// teams, until the PR has the team's required_reviewers. excluded users are
+// never picked and are extended with the new reviewers. Slots only reviewers
+// at capacity could fill overflow or queue the PR, and missing candidates
+// leave the PR short, as on creation.
+func (s *PullRequestService) topUpReviewers(pr *api.PullRequest, excluded map[string]bool) error {
+	required := s.requiredReviewers(pr.TeamName)
+	if len(pr.AssignedReviewers) >= required {
+		return nil
 	}
 
-	var reviewers []string
-	selected := 0
-	used := make(map[int]bool)
-
-	for selected < count {
-		idx, err := randomIndex(len(members))
-		if err != nil {
-			break // в реальном коде лучше обработать ошибку
-		}
-		if !used[idx] {
-			reviewers = append(reviewers, members[idx].UserId)
-			used[idx] = true
-			selected++
+	members, err := s.activeTeamMembers(pr.TeamName, pr.AuthorId)
+	if err != nil {
+		return err
+	}
+	var candidates []api.TeamMember
+	for _, member := range members {
+		if !excluded[member.UserId] {
+			candidates = append(candidates, member)
 		}
 	}
-	return reviewers
-}
-
-func (s *PullRequestService) CreatePR(pr *api.PullRequest) error {
-	activeMembers, err := s.GetActiveTeamMembers(pr.AuthorId)
+	selected, err := s.selectReviewers(pr.TeamName, pr.AuthorId, candidates, required-len(pr.AssignedReviewers))
 	if err != nil {
 		return err
 	}
+	pr.AssignedReviewers = append(pr.AssignedReviewers, selected...)
+	for _, reviewer := range selected {
+		excluded[reviewer] = true
+	}
 
-	reviewers := s.SelectRandomReviewers(activeMembers, 2)
-	pr.AssignedReviewers = reviewers
-	pr.Status = api.PullRequestStatusOPEN
-	now := time.Now()
-	pr.CreatedAt = &now
-
-	return s.pullRequestRepository.CreatePR(*pr)
-}
-
-func (s *PullRequestService) FindPRByID(prID string) (*api.PullRequest, error) {
-	return s.pullRequestRepository.FindPRByID(prID)
-}
-
-func (s *PullRequestService) MergePR(prID string) (*api.PullRequest, error) {
-	pr, err := s.pullRequestRepository.FindPRByID(prID)
+	fallback, err := s.fallbackReviewers(pr.TeamName, pr.AuthorId, excluded, required-len(pr.AssignedReviewers))
 	if err != nil {
-		return nil, fmt.Errorf("PR not found")
+		return err
 	}
-
-	if pr.Status != api.PullRequestStatusMERGED {
-		pr.Status = api.PullRequestStatusMERGED
-		now := time.Now()
-		pr.MergedAt = &now
-		err = s.pullRequestRepository.UpdatePR(*pr)
-		if err != nil {
-			return nil, err
-		}
+	pr.AssignedReviewers = append(pr.AssignedReviewers, fallback...)
+	addFallbackReviewers(pr, fallback)
+	for _, reviewer := range fallback {
+		excluded[reviewer] = true
 	}
 
-	return pr, nil
+	_, err = s.fillOverCapacity(pr, pr.TeamName, required, excluded)
+	return err
 }
 
 func (s *PullRequestService) ReassignReviewer(prID string, oldReviewerID string) (*api.PullRequest, *string, error) {
+	var pr *api.PullRequest
+	var newReviewer *string
+	err := retryOnConflict(func() error {
+		var err error
+		pr, newReviewer, err = s.reassignReviewer(prID, oldReviewerID, false)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return pr, newReviewer, nil
+}
+
+// reassignReviewer replaces oldReviewerID on the PR, with a senior when the
+// PR would otherwise fall short of its team's min_senior_reviewers. When only
+// reviewers at capacity are left the slot overflows or the PR is queued, and
+// the new reviewer is nil in the latter case. overdue records the
+// replacement as an SLA escalation as well.
+func (s *PullRequestService) reassignReviewer(prID string, oldReviewerID string, overdue bool) (*api.PullRequest, *string, error) {
 	pr, err := s.pullRequestRepository.FindPRByID(prID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("PR not found")
+		return nil, nil, err
 	}
 
 	if pr.Status == api.PullRequestStatusMERGED {
-		return nil, nil, fmt.Errorf("cannot reassign on merged PR")
+		return nil, nil, domain.ErrPRMerged
+	}
+	if pr.Status == api.PullRequestStatusCLOSED {
+		return nil, nil, domain.ErrPRClosed
 	}
 
 	found := false
@@ -153,70 +1424,245 @@ func (s *PullRequestService) ReassignReviewer(prID string, oldReviewerID string)
 		}
 	}
 	if !found {
-		return nil, nil, fmt.Errorf("reviewer is not assigned to this PR")
+		return nil, nil, domain.ErrNotAssigned
 	}
 
 	oldReviewer, err := s.userRepository.FindUserByID(oldReviewerID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil, domain.ErrReviewerNotFound
+	}
 	if err != nil {
-		return nil, nil, fmt.Errorf("reviewer not found")
+		return nil, nil, err
+	}
+
+	teamName := replacementTeam(pr, oldReviewer)
+	var members []api.TeamMember
+	if teamName != "" {
+		members, err = s.activeTeamMembers(teamName, oldReviewerID)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
-	members, err := s.teamRepository.FindTeamMembersByName(oldReviewer.TeamName)
+	excluded := map[string]bool{pr.AuthorId: true, oldReviewerID: true}
+	var remaining []string
+	for _, reviewer := range pr.AssignedReviewers {
+		excluded[reviewer] = true
+		if reviewer != oldReviewerID {
+			remaining = append(remaining, reviewer)
+		}
+	}
+	missingSeniors, err := s.missingSeniors(pr.TeamName, remaining)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get team members")
+		return nil, nil, err
+	}
+	if missingSeniors > 0 && teamName != "" {
+		excluded, err = s.seniorOnly(teamName, excluded)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	var candidates []api.TeamMember
 	for _, member := range members {
-		if !member.IsActive {
-			continue
-		}
-		if member.UserId == oldReviewerID {
-			continue
-		}
-		alreadyReviewer := false
-		for _, reviewer := range pr.AssignedReviewers {
-			if reviewer == member.UserId {
-				alreadyReviewer = true
-				break
-			}
-		}
-		if !alreadyReviewer {
+		if !excluded[member.UserId] {
 			candidates = append(candidates, member)
 		}
 	}
 
-	if len(candidates) == 0 {
-		return nil, nil, fmt.Errorf("no active replacement candidate in team")
-	}
-
-	idx, err := randomIndex(len(candidates))
+	selected, err := s.selectReviewers(teamName, pr.AuthorId, candidates, 1)
 	if err != nil {
 		return nil, nil, err
 	}
-	newReviewer := candidates[idx].UserId
+	var fallback []string
+	if len(selected) == 0 {
+		fallback, err = s.fallbackReviewers(teamName, pr.AuthorId, excluded, 1)
+		if err != nil {
+			return nil, nil, err
+		}
+		selected = fallback
+	}
 
-	newReviewers := []string{}
-	for _, reviewer := range pr.AssignedReviewers {
-		if reviewer != oldReviewerID {
-			newReviewers = append(newReviewers, reviewer)
+	removeReviewer(pr, oldReviewerID)
+	kept := len(pr.AssignedReviewers)
+	pr.AssignedReviewers = append(pr.AssignedReviewers, selected...)
+	addFallbackReviewers(pr, fallback)
+	if len(selected) == 0 {
+		outcome, err := s.fillOverCapacity(pr, teamName, kept+1, excluded)
+		if err != nil {
+			return nil, nil, err
+		}
+		if outcome == capacityOK && missingSeniors > 0 {
+			return nil, nil, domain.ErrNoSeniorCandidate
 		}
+		if outcome == capacityOK {
+			return nil, nil, domain.ErrNoReplacementCandidate
+		}
+	}
+	added := pr.AssignedReviewers[kept:]
+	var newReviewer *string
+	if len(added) > 0 {
+		newReviewer = &added[0]
 	}
-	newReviewers = append(newReviewers, newReviewer)
-	pr.AssignedReviewers = newReviewers
 
-	err = s.pullRequestRepository.UpdatePR(*pr)
+	event, err := newOutboxEvent(EventReviewersChanged, s.clock.Now(), *pr, added, []string{oldReviewerID})
+	if err != nil {
+		return nil, nil, err
+	}
+	records := s.assignmentRecords(*pr, api.ReviewerAssignmentActionReassign, added, oldReviewerID)
+	if overdue {
+		records = append(records, repository.EscalationRecord{
+			PullRequestId: prID,
+			UserId:        oldReviewerID,
+			Stage:         api.ReviewEscalationStageReassignment,
+			NewUserId:     newReviewer,
+			EscalatedAt:   s.clock.Now(),
+		})
+	}
+	err = s.savePR(pr, append(records, event)...)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return pr, &newReviewer, nil
+	return pr, newReviewer, nil
+}
+
+// remindReviewer records a reminder for a reviewer of an open PR and sends
+// it as an event.
+func (s *PullRequestService) remindReviewer(prID string, userID string) error {
+	return retryOnConflict(func() error {
+		pr, err := s.pullRequestRepository.FindPRByID(prID)
+		if err != nil {
+			return err
+		}
+		if pr.Status != api.PullRequestStatusOPEN || !containsReviewer(*pr, userID) {
+			return nil
+		}
+
+		now := s.clock.Now()
+		event := newEvent(EventReviewReminder, now, *pr, nil, nil)
+		event.OverdueReviewer = userID
+		outboxEvent, err := encodeOutboxEvent(event)
+		if err != nil {
+			return err
+		}
+		reminder := repository.EscalationRecord{
+			PullRequestId: prID,
+			UserId:        userID,
+			Stage:         api.ReviewEscalationStageReminder,
+			EscalatedAt:   now,
+		}
+		return s.savePR(pr, reminder, outboxEvent)
+	})
+}
+
+// reassignOverdueReviewer replaces a reviewer that ignored a reminder, the
+// same way ReassignReviewer does.
+func (s *PullRequestService) reassignOverdueReviewer(prID string, userID string) (*string, error) {
+	var newReviewer *string
+	err := retryOnConflict(func() error {
+		var err error
+		_, newReviewer, err = s.reassignReviewer(prID, userID, true)
+		return err
+	})
+	return newReviewer, err
+}
+
+// GetEscalations returns the SLA reminders and reassignments of a PR in the
+// order they were made.
+func (s *PullRequestService) GetEscalations(prID string) ([]api.ReviewEscalation, error) {
+	if _, err := s.pullRequestRepository.FindPRByID(prID); err != nil {
+		return nil, err
+	}
+	return s.pullRequestRepository.FindEscalationsByPR(prID)
 }
 
 func (s *PullRequestService) FindPRsByReviewer(userID string) ([]api.PullRequest, error) {
 	return s.pullRequestRepository.FindPRsByReviewer(userID)
 }
 
+// ListPRs returns a page of at most limit PRs matching filter and the cursor
+// of the next page, which is empty on the last one. sortBy and order default
+// to created_at and asc; cursor continues a previous listing made with the
+// same filter and sorting.
+func (s *PullRequestService) ListPRs(filter repository.PRFilter, sortBy string, order string, cursor string, limit int) ([]api.PullRequest, string, error) {
+	switch filter.Status {
+	case "", api.PullRequestStatusOPEN, api.PullRequestStatusMERGED, api.PullRequestStatusCLOSED:
+	default:
+		return nil, "", domain.ErrInvalidStatus
+	}
+
+	switch repository.PRSortField(sortBy) {
+	case "", repository.PRSortCreatedAt:
+		filter.SortBy = repository.PRSortCreatedAt
+	case repository.PRSortName:
+		filter.SortBy = repository.PRSortName
+	default:
+		return nil, "", domain.ErrInvalidSort
+	}
+	switch order {
+	case "", "asc":
+	case "desc":
+		filter.Descending = true
+	default:
+		return nil, "", domain.ErrInvalidSort
+	}
+
+	if cursor != "" {
+		after, err := decodePRCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter.After = after
+	}
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	filter.Limit = limit + 1
+
+	prs, err := s.pullRequestRepository.ListPRs(filter)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(prs) <= limit {
+		return prs, "", nil
+	}
+
+	prs = prs[:limit]
+	next, err := encodePRCursor(prs[limit-1])
+	if err != nil {
+		return nil, "", err
+	}
+	return prs, next, nil
+}
+
+func encodePRCursor(pr api.PullRequest) (string, error) {
+	position := repository.PRCursor{Name: pr.PullRequestName, PullRequestID: pr.PullRequestId}
+	if pr.CreatedAt != nil {
+		position.CreatedAt = *pr.CreatedAt
+	}
+	data, err := json.Marshal(position)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodePRCursor(cursor string) (*repository.PRCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, domain.Wrap(domain.ErrInvalidCursor, err)
+	}
+	var position repository.PRCursor
+	if err := json.Unmarshal(data, &position); err != nil {
+		return nil, domain.Wrap(domain.ErrInvalidCursor, err)
+	}
+	if position.PullRequestID == "" {
+		return nil, domain.ErrInvalidCursor
+	}
+	return &position, nil
+}
+
 func (s *PullRequestService) GetStatistics() (*api.Statistics, error) {
 	stats := &api.Statistics{
 		TotalAssignments: 0,
@@ -224,6 +1670,7 @@ func (s *PullRequestService) GetStatistics() (*api.Statistics, error) {
 		ByStatus: struct {
 			Open   int `json:"open"`
 			Merged int `json:"merged"`
+			Closed int `json:"closed"`
 		}{},
 	}
 
@@ -238,10 +1685,13 @@ func (s *PullRequestService) GetStatistics() (*api.Statistics, error) {
 			stats.ByUser[reviewer]++
 		}
 
-		if pr.Status == api.PullRequestStatusOPEN {
+		switch pr.Status {
+		case api.PullRequestStatusOPEN:
 			stats.ByStatus.Open++
-		} else if pr.Status == api.PullRequestStatusMERGED {
+		case api.PullRequestStatusMERGED:
 			stats.ByStatus.Merged++
+		case api.PullRequestStatusCLOSED:
+			stats.ByStatus.Closed++
 		}
 	}
 
@@ -251,7 +1701,7 @@ func (s *PullRequestService) GetStatistics() (*api.Statistics, error) {
 func (s *PullRequestService) DeactivateUsersAndReassignPRs(teamName string, userIDs []string) (*api.BatchDeactivateResponse, error) {
 	team := s.teamRepository.FindTeamByName(teamName)
 	if team.TeamName == "" {
-		return nil, fmt.Errorf("team not found")
+		return nil, domain.ErrTeamNotFound
 	}
 
 	response := &api.BatchDeactivateResponse{
@@ -268,16 +1718,44 @@ func (s *PullRequestService) DeactivateUsersAndReassignPRs(teamName string, user
 		userIDMap[userID] = true
 	}
 
-	var activeReplacements []string
+	away, err := s.awayUsers()
+	if err != nil {
+		return nil, err
+	}
+	var activeReplacements []api.TeamMember
 	allUsers, _ := s.userRepository.GetAllUsers()
 	for _, user := range allUsers {
-		if user.TeamName == teamName && user.IsActive && !userIDMap[user.UserId] {
-			activeReplacements = append(activeReplacements, user.UserId)
+		if memberOf(&user, teamName) && user.IsActive && !away[user.UserId] && !userIDMap[user.UserId] {
+			activeReplacements = append(activeReplacements, api.TeamMember{
+				UserId:   user.UserId,
+				Username: user.Username,
+				IsActive: user.IsActive,
+			})
 		}
 	}
 
 	if len(activeReplacements) == 0 {
-		return nil, fmt.Errorf("no active team members available for reassignment")
+		return nil, domain.ErrNoActiveMembers
+	}
+	// Replacements come from the team of each PR, which can differ from
+	// teamName for members of several teams.
+	replacements := map[string][]api.TeamMember{teamName: activeReplacements}
+	replacementsFor := func(prTeam string) ([]api.TeamMember, error) {
+		if candidates, ok := replacements[prTeam]; ok {
+			return candidates, nil
+		}
+		members, err := s.activeTeamMembers(prTeam, "")
+		if err != nil {
+			return nil, err
+		}
+		var candidates []api.TeamMember
+		for _, member := range members {
+			if !userIDMap[member.UserId] {
+				candidates = append(candidates, member)
+			}
+		}
+		replacements[prTeam] = candidates
+		return candidates, nil
 	}
 
 	for _, userID := range userIDs {
@@ -293,34 +1771,240 @@ func (s *PullRequestService) DeactivateUsersAndReassignPRs(teamName string, user
 
 		prs, _ := s.pullRequestRepository.FindPRsByReviewer(userID)
 		for _, pr := range prs {
-			if pr.Status != api.PullRequestStatusOPEN {
+			prTeam := pr.TeamName
+			if prTeam == "" {
+				prTeam = teamName
+			}
+			candidates, err := replacementsFor(prTeam)
+			if err != nil {
+				return nil, err
+			}
+			reassigned, outcome, err := s.replaceReviewer(pr, userID, prTeam, userIDs, candidates, s.requiredReviewers(prTeam), api.ReviewerAssignmentActionDeactivation)
+			if err != nil {
+				return nil, err
+			}
+			if reassigned {
+				response.ReassignedCount++
+			}
+			switch outcome {
+			case capacityOverflow:
+				response.OverflowCount++
+			case capacityQueued:
+				response.QueuedCount++
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// ReleaseReviews takes userIDs off the open PRs of teamName they review and
+// refills the slots from the other active members of teamName, then from its
+// fallback teams. Reviews on PRs of other teams are kept. It returns the
+// number of released reviews.
+func (s *PullRequestService) ReleaseReviews(teamName string, userIDs []string) (int, error) {
+	leaving := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		leaving[userID] = true
+	}
+	members, err := s.activeTeamMembers(teamName, "")
+	if err != nil {
+		return 0, err
+	}
+	var replacements []api.TeamMember
+	for _, member := range members {
+		if !leaving[member.UserId] {
+			replacements = append(replacements, member)
+		}
+	}
+	requiredReviewers := s.requiredReviewers(teamName)
+
+	released := 0
+	for _, userID := range userIDs {
+		prs, err := s.pullRequestRepository.FindPRsByReviewer(userID)
+		if err != nil {
+			return released, err
+		}
+		for _, pr := range prs {
+			if pr.TeamName != teamName {
 				continue
 			}
+			reassigned, _, err := s.replaceReviewer(pr, userID, teamName, userIDs, replacements, requiredReviewers, api.ReviewerAssignmentActionTeamRemoval)
+			if err != nil {
+				return released, err
+			}
+			if reassigned {
+				released++
+			}
+		}
+	}
+	return released, nil
+}
 
-			var newReviewers []string
-			for _, rev := range pr.AssignedReviewers {
-				if rev != userID {
-					newReviewers = append(newReviewers, rev)
-				}
+// HandOffReviews takes userID off every open PR they review. Each slot is
+// refilled from the team a reassignment would use, then from its fallback
+// teams. It returns the number of released reviews.
+func (s *PullRequestService) HandOffReviews(userID string) (int, error) {
+	return s.handOffReviews(userID, nil, api.ReviewerAssignmentActionDeactivation)
+}
+
+// HandOffAwayReviews hands the open reviews of a user that went away to
+// delegateID where the delegate is active, not away and can review the PR,
+// and to other reviewers the way HandOffReviews does otherwise.
+func (s *PullRequestService) HandOffAwayReviews(userID string, delegateID *string) (int, error) {
+	return s.handOffReviews(userID, delegateID, api.ReviewerAssignmentActionAway)
+}
+
+func (s *PullRequestService) handOffReviews(userID string, delegateID *string, action api.ReviewerAssignmentAction) (int, error) {
+	user, err := s.userRepository.FindUserByID(userID)
+	if err != nil {
+		return 0, err
+	}
+	prs, err := s.pullRequestRepository.FindPRsByReviewer(userID)
+	if err != nil {
+		return 0, err
+	}
+	delegate, err := s.availableDelegate(delegateID)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, pr := range prs {
+		teamName := replacementTeam(&pr, user)
+		var candidates []api.TeamMember
+		if delegate != nil && delegate.UserId != pr.AuthorId && !containsReviewer(pr, delegate.UserId) {
+			candidates = []api.TeamMember{*delegate}
+		} else if teamName != "" {
+			candidates, err = s.activeTeamMembers(teamName, userID)
+			if err != nil {
+				return released, err
+			}
+		}
+		reassigned, _, err := s.replaceReviewer(pr, userID, teamName, []string{userID}, candidates, s.requiredReviewers(teamName), action)
+		if err != nil {
+			return released, err
+		}
+		if reassigned {
+			released++
+		}
+	}
+	return released, nil
+}
+
+// availableDelegate returns delegateID as a candidate when the user exists,
+// is active and is not away, and nil otherwise.
+func (s *PullRequestService) availableDelegate(delegateID *string) (*api.TeamMember, error) {
+	if delegateID == nil {
+		return nil, nil
+	}
+	delegate, err := s.userRepository.FindUserByID(*delegateID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	away, err := s.awayUsers()
+	if err != nil {
+		return nil, err
+	}
+	if !delegate.IsActive || away[delegate.UserId] {
+		return nil, nil
+	}
+	return &api.TeamMember{UserId: delegate.UserId, Username: delegate.Username, IsActive: true}, nil
+}
+
+// replaceReviewer removes userID from an open PR and tops it up to
+// requiredReviewers, logging the new reviewers with action, and reports how
+// slots left to reviewers at capacity were handled. When the PR changes
+// concurrently it is read again and the replacement is redone.
+func (s *PullRequestService) replaceReviewer(
+	pr api.PullRequest,
+	userID string,
+	teamName string,
+	userIDs []string,
+	activeReplacements []api.TeamMember,
+	requiredReviewers int,
+	action api.ReviewerAssignmentAction,
+) (bool, capacityOutcome, error) {
+	reassigned := false
+	outcome := capacityOK
+	reload := false
+	err := retryOnConflict(func() error {
+		if reload {
+			fresh, err := s.pullRequestRepository.FindPRByID(pr.PullRequestId)
+			if err != nil {
+				return err
 			}
+			pr = *fresh
+		}
+		reload = true
+		outcome = capacityOK
+
+		if pr.Status != api.PullRequestStatusOPEN || !containsReviewer(pr, userID) {
+			return nil
+		}
+
+		removeReviewer(&pr, userID)
+		kept := len(pr.AssignedReviewers)
+		excluded := map[string]bool{pr.AuthorId: true}
+		for _, deactivatedID := range userIDs {
+			excluded[deactivatedID] = true
+		}
+		for _, rev := range pr.AssignedReviewers {
+			excluded[rev] = true
+		}
 
-			if len(newReviewers) < 2 && len(activeReplacements) > 0 {
-				replacementIndex, err := randomIndex(len(activeReplacements))
-				if err != nil {
-					continue
+		if len(pr.AssignedReviewers) < requiredReviewers {
+			var candidates []api.TeamMember
+			for _, member := range activeReplacements {
+				if !excluded[member.UserId] {
+					candidates = append(candidates, member)
 				}
-				replacement := activeReplacements[replacementIndex]
-				newReviewers = append(newReviewers, replacement)
 			}
 
-			pr.AssignedReviewers = newReviewers
-			err := s.pullRequestRepository.UpdatePR(pr)
+			replacements, err := s.selectReviewers(teamName, pr.AuthorId, candidates, requiredReviewers-len(pr.AssignedReviewers))
 			if err != nil {
-				return nil, err
+				return err
+			}
+			pr.AssignedReviewers = append(pr.AssignedReviewers, replacements...)
+			for _, replacement := range replacements {
+				excluded[replacement] = true
+			}
+
+			fallback, err := s.fallbackReviewers(teamName, pr.AuthorId, excluded, requiredReviewers-len(pr.AssignedReviewers))
+			if err != nil {
+				return err
+			}
+			pr.AssignedReviewers = append(pr.AssignedReviewers, fallback...)
+			addFallbackReviewers(&pr, fallback)
+
+			outcome, err = s.fillOverCapacity(&pr, teamName, requiredReviewers, excluded)
+			if err != nil {
+				return err
 			}
-			response.ReassignedCount++
 		}
-	}
 
-	return response, nil
+		event, err := newOutboxEvent(EventReviewersChanged, s.clock.Now(), pr, pr.AssignedReviewers[kept:], []string{userID})
+		if err != nil {
+			return err
+		}
+		records := s.assignmentRecords(pr, action, pr.AssignedReviewers[kept:], userID)
+		if err := s.savePR(&pr, append(records, event)...); err != nil {
+			return err
+		}
+		reassigned = true
+		return nil
+	})
+	return reassigned, outcome, err
+}
+
+func containsReviewer(pr api.PullRequest, userID string) bool {
+	for _, reviewer := range pr.AssignedReviewers {
+		if reviewer == userID {
+			return true
+		}
+	}
+	return false
 }
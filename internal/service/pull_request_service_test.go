@@ -1,9 +1,16 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/config"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository/inmemory"
 )
 
@@ -54,7 +61,7 @@ func TestMergePR(t *testing.T) {
 		return
 	}
 
-	mergedPR, err := service.MergePR("pr-1")
+	mergedPR, err := service.MergePR("pr-1", false)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -67,7 +74,7 @@ func TestMergePR(t *testing.T) {
 		t.Error("Expected merged_at to be set")
 	}
 
-	mergedPR2, err := service.MergePR("pr-1")
+	mergedPR2, err := service.MergePR("pr-1", false)
 	if err != nil {
 		t.Fatalf("Expected no error on second merge, got %v", err)
 	}
@@ -77,6 +84,173 @@ func TestMergePR(t *testing.T) {
 	}
 }
 
+func TestMergePRRequiresApprovals(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	service := NewPullRequestService(prRepo, inmemory.NewTeamRepository(), inmemory.NewUserRepository(),
+		WithReviewsConfig(config.ReviewsConfig{RequiredApprovals: 2}))
+
+	for _, prID := range []string{"pr-1", "pr-2"} {
+		_ = prRepo.CreatePR(api.PullRequest{
+			PullRequestId:     prID,
+			AuthorId:          "u1",
+			Status:            api.PullRequestStatusOPEN,
+			AssignedReviewers: []string{"u2", "u3"},
+		})
+	}
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId:     "pr-3",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{},
+	})
+
+	if _, err := service.MergePR("pr-1", false); !errors.Is(err, domain.ErrNotApproved) {
+		t.Errorf("Expected not approved error without reviews, got %v", err)
+	}
+	if _, _, err := service.SubmitReview("pr-1", "u4", api.ReviewDecisionDecisionApproved); !errors.Is(err, domain.ErrNotAssigned) {
+		t.Errorf("Expected not assigned error, got %v", err)
+	}
+	if _, _, err := service.SubmitReview("pr-1", "u2", "rejected"); !errors.Is(err, domain.ErrInvalidDecision) {
+		t.Errorf("Expected invalid decision error, got %v", err)
+	}
+
+	_, _, _ = service.SubmitReview("pr-1", "u2", api.ReviewDecisionDecisionApproved)
+	_, _, _ = service.SubmitReview("pr-1", "u3", api.ReviewDecisionDecisionApproved)
+	_, review, err := service.SubmitReview("pr-1", "u3", api.ReviewDecisionDecisionChangesRequested)
+	if err != nil || review.Decision != api.ReviewDecisionDecisionChangesRequested || review.SubmittedAt.IsZero() {
+		t.Fatalf("Expected changes requested by u3, got %+v %v", review, err)
+	}
+	if _, err := service.MergePR("pr-1", false); !errors.Is(err, domain.ErrNotApproved) {
+		t.Errorf("Expected the latest decision of u3 to count, got %v", err)
+	}
+
+	_, _, _ = service.SubmitReview("pr-1", "u3", api.ReviewDecisionDecisionApproved)
+	merged, err := service.MergePR("pr-1", false)
+	if err != nil || merged.Status != api.PullRequestStatusMERGED {
+		t.Errorf("Expected merge with two approvals, got %+v %v", merged, err)
+	}
+	if _, _, err := service.SubmitReview("pr-1", "u2", api.ReviewDecisionDecisionCommented); !errors.Is(err, domain.ErrPRAlreadyMerged) {
+		t.Errorf("Expected already merged error on review, got %v", err)
+	}
+
+	merged, err = service.MergePR("pr-2", true)
+	if err != nil || merged.Status != api.PullRequestStatusMERGED {
+		t.Errorf("Expected admin override to merge, got %+v %v", merged, err)
+	}
+
+	if _, err := service.MergePR("pr-3", false); !errors.Is(err, domain.ErrNotApproved) {
+		t.Errorf("Expected a PR without reviewers not to merge, got %v", err)
+	}
+	merged, err = service.MergePR("pr-3", true)
+	if err != nil || merged.Status != api.PullRequestStatusMERGED {
+		t.Errorf("Expected admin override to merge a PR without reviewers, got %+v %v", merged, err)
+	}
+}
+
+func TestDraftPRDefersReviewerAssignment(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepositoryWithUsers(userRepo)
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+	}})
+
+	pr := &api.PullRequest{PullRequestId: "pr-1", PullRequestName: "WIP", AuthorId: "u1"}
+	if err := service.CreatePR(pr, CreatePROptions{Draft: true}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !pr.IsDraft || len(pr.AssignedReviewers) != 0 {
+		t.Errorf("Expected a draft without reviewers, got %+v", pr)
+	}
+	if _, err := service.MergePR("pr-1", false); !errors.Is(err, domain.ErrPRDraft) {
+		t.Errorf("Expected draft error on merge, got %v", err)
+	}
+
+	ready, err := service.ReadyForReview("pr-1", CreatePROptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ready.IsDraft || fmt.Sprint(ready.AssignedReviewers) != "[u2]" {
+		t.Errorf("Expected u2 assigned once ready, got %+v", ready)
+	}
+	history, _ := service.GetHistory("pr-1")
+	if len(history) != 1 || history[0].UserId != "u2" || history[0].Action != api.ReviewerAssignmentActionCreate {
+		t.Errorf("Expected u2 logged as create, got %+v", history)
+	}
+
+	again, err := service.ReadyForReview("pr-1", CreatePROptions{})
+	if err != nil || again.IsDraft || fmt.Sprint(again.AssignedReviewers) != "[u2]" {
+		t.Errorf("Expected the ready PR unchanged, got %+v %v", again, err)
+	}
+}
+
+func TestCloseAndReopenPR(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepositoryWithUsers(userRepo)
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+		{UserId: "u4", Username: "Diana", IsActive: true},
+	}})
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		TeamName:          "backend",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2", "u3"},
+	})
+
+	closed, err := service.ClosePR("pr-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if closed.Status != api.PullRequestStatusCLOSED || closed.ClosedAt == nil {
+		t.Errorf("Expected closed PR with closedAt, got %+v", closed)
+	}
+	if _, _, err := service.ReassignReviewer("pr-1", "u2"); !errors.Is(err, domain.ErrPRClosed) {
+		t.Errorf("Expected PR closed error on reassign, got %v", err)
+	}
+	if _, err := service.MergePR("pr-1", false); !errors.Is(err, domain.ErrPRClosed) {
+		t.Errorf("Expected PR closed error on merge, got %v", err)
+	}
+	stats, _ := service.GetStatistics()
+	if stats.ByStatus.Closed != 1 || stats.ByStatus.Open != 0 {
+		t.Errorf("Expected one closed PR in stats, got %+v", stats.ByStatus)
+	}
+
+	_ = userRepo.UpdateUserStatus("u3", false)
+	reopened, err := service.ReopenPR("pr-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if reopened.Status != api.PullRequestStatusOPEN || reopened.ClosedAt != nil {
+		t.Errorf("Expected open PR, got %+v", reopened)
+	}
+	if fmt.Sprint(reopened.AssignedReviewers) != "[u2 u4]" {
+		t.Errorf("Expected inactive u3 replaced by u4, got %v", reopened.AssignedReviewers)
+	}
+	history, _ := service.GetHistory("pr-1")
+	last := history[len(history)-1]
+	if last.UserId != "u4" || last.Action != api.ReviewerAssignmentActionReopen || last.ReplacedUserId == nil || *last.ReplacedUserId != "u3" {
+		t.Errorf("Expected u4 logged as reopen replacing u3, got %+v", last)
+	}
+
+	_, _ = service.MergePR("pr-1", false)
+	if _, err := service.ClosePR("pr-1"); !errors.Is(err, domain.ErrPRAlreadyMerged) {
+		t.Errorf("Expected already merged error on close, got %v", err)
+	}
+	if _, err := service.ReopenPR("pr-1"); !errors.Is(err, domain.ErrPRAlreadyMerged) {
+		t.Errorf("Expected already merged error on reopen, got %v", err)
+	}
+}
+
 func TestReassignReviewerForbiddenOnMerged(t *testing.T) {
 	prRepo := inmemory.NewPullRequestRepository()
 	userRepo := inmemory.NewUserRepository()
@@ -121,3 +295,719 @@ func TestReassignReviewerNotAssigned(t *testing.T) {
 		t.Fatal("Expected error when reassigning reviewer who is not assigned")
 	}
 }
+
+func TestCreatePRRespectsRequiredReviewers(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepository()
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+
+	requiredReviewers := 3
+	err := teamRepo.CreateTeam(api.Team{
+		TeamName:          "security",
+		RequiredReviewers: &requiredReviewers,
+		Members: []api.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+			{UserId: "u3", Username: "Charlie", IsActive: true},
+			{UserId: "u4", Username: "Diana", IsActive: true},
+			{UserId: "u5", Username: "Eve", IsActive: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	userRepo.AddUser(&api.User{UserId: "u1", Username: "Alice", IsActive: true, TeamName: "security"})
+
+	pr := &api.PullRequest{PullRequestId: "pr-1", PullRequestName: "Rotate keys", AuthorId: "u1"}
+	if err := service.CreatePR(pr, CreatePROptions{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pr.AssignedReviewers) != 3 {
+		t.Errorf("Expected 3 reviewers, got %d", len(pr.AssignedReviewers))
+	}
+}
+
+func TestDeactivateUsersRefillsToRequiredReviewers(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepository()
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+
+	requiredReviewers := 1
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", RequiredReviewers: &requiredReviewers, Members: []api.TeamMember{}})
+	for _, userID := range []string{"u1", "u2", "u3", "u4"} {
+		userRepo.AddUser(&api.User{UserId: userID, IsActive: true, TeamName: "backend"})
+	}
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2"},
+	})
+
+	_, err := service.DeactivateUsersAndReassignPRs("backend", []string{"u2"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	pr, _ := prRepo.FindPRByID("pr-1")
+	if len(pr.AssignedReviewers) != 1 {
+		t.Fatalf("Expected 1 reviewer, got %v", pr.AssignedReviewers)
+	}
+	if pr.AssignedReviewers[0] == "u1" || pr.AssignedReviewers[0] == "u2" {
+		t.Errorf("Expected replacement other than author and deactivated user, got %s", pr.AssignedReviewers[0])
+	}
+}
+
+func TestDeactivateUsersReplacesFromPRTeam(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepositoryWithUsers(userRepo)
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+
+	one, two := 1, 2
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", RequiredReviewers: &one, Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+	}})
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "frontend", RequiredReviewers: &two, Members: []api.TeamMember{
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u4", Username: "Diana", IsActive: true},
+		{UserId: "u5", Username: "Eve", IsActive: true},
+		{UserId: "u6", Username: "Frank", IsActive: true},
+	}})
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId: "pr-1", AuthorId: "u4", TeamName: "frontend",
+		Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"},
+	})
+
+	if _, err := service.DeactivateUsersAndReassignPRs("backend", []string{"u2"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	pr, _ := prRepo.FindPRByID("pr-1")
+	if len(pr.AssignedReviewers) != 2 || !containsReviewer(*pr, "u5") || !containsReviewer(*pr, "u6") {
+		t.Errorf("Expected two frontend reviewers, got %v", pr.AssignedReviewers)
+	}
+}
+
+type failingSelector struct{}
+
+func (failingSelector) SelectReviewers(SelectionRequest) ([]string, error) {
+	return nil, errors.New("selection failed")
+}
+
+func TestDeactivateUsersReportsSelectionErrors(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepository()
+	service := NewPullRequestService(prRepo, teamRepo, userRepo,
+		WithReviewerSelector(api.TeamSelectionStrategyRandom, failingSelector{}))
+
+	requiredReviewers := 1
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", RequiredReviewers: &requiredReviewers, Members: []api.TeamMember{}})
+	for _, userID := range []string{"u1", "u2", "u3"} {
+		userRepo.AddUser(&api.User{UserId: userID, IsActive: true, TeamName: "backend"})
+	}
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId: "pr-1", AuthorId: "u1", TeamName: "backend",
+		Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"},
+	})
+
+	if _, err := service.DeactivateUsersAndReassignPRs("backend", []string{"u2"}); err == nil {
+		t.Fatal("Expected the selection error to be returned")
+	}
+	pr, _ := prRepo.FindPRByID("pr-1")
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u2" {
+		t.Errorf("Expected the PR to keep its reviewer, got %v", pr.AssignedReviewers)
+	}
+}
+
+func TestCreatePRFillsFromFallbackTeams(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepository()
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "platform", Members: []api.TeamMember{
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+	}})
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "frontend", Members: []api.TeamMember{
+		{UserId: "u4", Username: "Diana", IsActive: true},
+	}})
+	fallbackTeams := []string{"platform", "frontend"}
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", FallbackTeams: &fallbackTeams, Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: false},
+	}})
+	userRepo.AddUser(&api.User{UserId: "u1", Username: "Alice", IsActive: true, TeamName: "backend"})
+
+	pr := &api.PullRequest{PullRequestId: "pr-1", PullRequestName: "Test PR", AuthorId: "u1"}
+	if err := service.CreatePR(pr, CreatePROptions{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u3" || pr.AssignedReviewers[1] != "u4" {
+		t.Fatalf("Expected reviewers [u3 u4], got %v", pr.AssignedReviewers)
+	}
+	if pr.FallbackReviewers == nil || len(*pr.FallbackReviewers) != 2 {
+		t.Errorf("Expected both reviewers marked as fallback, got %v", pr.FallbackReviewers)
+	}
+}
+
+func TestReassignReviewerUsesFallbackTeam(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepository()
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "platform", Members: []api.TeamMember{
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+	}})
+	fallbackTeams := []string{"platform"}
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", FallbackTeams: &fallbackTeams, Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+	}})
+	userRepo.AddUser(&api.User{UserId: "u2", Username: "Bob", IsActive: true, TeamName: "backend"})
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2"},
+	})
+
+	pr, newReviewer, err := service.ReassignReviewer("pr-1", "u2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if *newReviewer != "u3" {
+		t.Errorf("Expected u3 from fallback team, got %s", *newReviewer)
+	}
+	if pr.FallbackReviewers == nil || len(*pr.FallbackReviewers) != 1 || (*pr.FallbackReviewers)[0] != "u3" {
+		t.Errorf("Expected u3 marked as fallback, got %v", pr.FallbackReviewers)
+	}
+}
+
+func TestCreatePRSelectsFromRequestedTeam(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepositoryWithUsers(userRepo)
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+	}})
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "security", Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+	}})
+
+	author, _ := userRepo.FindUserByID("u1")
+	if len(author.Teams) != 2 || author.TeamName != "backend" {
+		t.Fatalf("Expected u1 in backend and security, got %+v", author)
+	}
+
+	pr := &api.PullRequest{PullRequestId: "pr-1", PullRequestName: "Default team", AuthorId: "u1"}
+	if err := service.CreatePR(pr, CreatePROptions{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if pr.TeamName != "backend" || len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u2" {
+		t.Errorf("Expected backend PR reviewed by u2, got %s %v", pr.TeamName, pr.AssignedReviewers)
+	}
+
+	pr = &api.PullRequest{PullRequestId: "pr-2", PullRequestName: "Security fix", AuthorId: "u1", TeamName: "security"}
+	if err := service.CreatePR(pr, CreatePROptions{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if pr.TeamName != "security" || len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u3" {
+		t.Errorf("Expected security PR reviewed by u3, got %s %v", pr.TeamName, pr.AssignedReviewers)
+	}
+
+	pr = &api.PullRequest{PullRequestId: "pr-3", PullRequestName: "Wrong team", AuthorId: "u2", TeamName: "security"}
+	if err := service.CreatePR(pr, CreatePROptions{}); !errors.Is(err, domain.ErrAuthorNotTeamMember) {
+		t.Errorf("Expected author not a member error, got %v", err)
+	}
+}
+
+func TestReassignReviewerUsesPRTeam(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepositoryWithUsers(userRepo)
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", Members: []api.TeamMember{
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u4", Username: "Diana", IsActive: true},
+	}})
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "security", Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+	}})
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		TeamName:          "security",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2"},
+	})
+
+	_, newReviewer, err := service.ReassignReviewer("pr-1", "u2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if *newReviewer != "u3" {
+		t.Errorf("Expected u3 from the PR's team, got %s", *newReviewer)
+	}
+}
+
+func TestMutationsReturnStoredVersion(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepositoryWithUsers(userRepo)
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+	}})
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		TeamName:          "backend",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2"},
+	})
+
+	assertStored := func(step string, returned *api.PullRequest) {
+		t.Helper()
+		stored, _ := prRepo.FindPRByID("pr-1")
+		if returned.Version != stored.Version {
+			t.Errorf("Expected %s to return stored version %d, got %d", step, stored.Version, returned.Version)
+		}
+	}
+
+	reassigned, _, err := service.ReassignReviewer("pr-1", "u2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assertStored("reassign", reassigned)
+
+	merged, err := service.MergePR("pr-1", false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assertStored("merge", merged)
+	if merged.Version != 2 {
+		t.Errorf("Expected version 2 after two changes, got %d", merged.Version)
+	}
+}
+
+func TestListPRsFiltersAndPaginates(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	service := NewPullRequestService(prRepo, inmemory.NewTeamRepository(), inmemory.NewUserRepository())
+
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		createdAt := base.Add(time.Duration(i) * time.Hour)
+		pr := api.PullRequest{
+			PullRequestId:     fmt.Sprintf("pr-%d", i),
+			PullRequestName:   fmt.Sprintf("Fix bug %d", 4-i),
+			AuthorId:          "u1",
+			TeamName:          "backend",
+			Status:            api.PullRequestStatusOPEN,
+			CreatedAt:         &createdAt,
+			AssignedReviewers: []string{"u2"},
+		}
+		if i%2 == 1 {
+			pr.AuthorId = "u3"
+			pr.PullRequestName = fmt.Sprintf("Add feature %d", i)
+			pr.Status = api.PullRequestStatusMERGED
+			pr.MergedAt = &createdAt
+			pr.AssignedReviewers = []string{"u4"}
+		}
+		_ = prRepo.CreatePR(pr)
+	}
+
+	ids := func(prs []api.PullRequest) []string {
+		result := []string{}
+		for _, pr := range prs {
+			result = append(result, pr.PullRequestId)
+		}
+		return result
+	}
+
+	var pages [][]string
+	cursor := ""
+	for {
+		prs, next, err := service.ListPRs(repository.PRFilter{}, "", "", cursor, 2)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		pages = append(pages, ids(prs))
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	if fmt.Sprint(pages) != "[[pr-0 pr-1] [pr-2 pr-3] [pr-4]]" {
+		t.Errorf("Expected three pages by created_at, got %v", pages)
+	}
+
+	createdTo := base.Add(4 * time.Hour)
+	prs, next, err := service.ListPRs(repository.PRFilter{
+		AuthorID:  "u1",
+		CreatedTo: &createdTo,
+		Name:      "FIX",
+	}, "pull_request_name", "desc", "", 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if fmt.Sprint(ids(prs)) != "[pr-0 pr-2]" || next != "" {
+		t.Errorf("Expected pr-0 and pr-2 by name desc, got %v %q", ids(prs), next)
+	}
+
+	mergedFrom := base.Add(2 * time.Hour)
+	prs, _, _ = service.ListPRs(repository.PRFilter{ReviewerID: "u4", MergedFrom: &mergedFrom}, "", "", "", 0)
+	if fmt.Sprint(ids(prs)) != "[pr-3]" {
+		t.Errorf("Expected pr-3 merged after the second hour, got %v", ids(prs))
+	}
+
+	if _, _, err := service.ListPRs(repository.PRFilter{}, "author", "", "", 0); !errors.Is(err, domain.ErrInvalidSort) {
+		t.Errorf("Expected invalid sort error, got %v", err)
+	}
+	if _, _, err := service.ListPRs(repository.PRFilter{}, "", "", "not a cursor", 0); !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Errorf("Expected invalid cursor error, got %v", err)
+	}
+}
+
+func TestGetHistoryRecordsAssignments(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepository()
+	created := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: created}
+	service := NewPullRequestService(prRepo, teamRepo, userRepo, WithClock(clock))
+
+	requiredReviewers := 1
+	members := []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+	}
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", RequiredReviewers: &requiredReviewers, Members: members})
+	for _, member := range members {
+		userRepo.AddUser(&api.User{UserId: member.UserId, Username: member.Username, IsActive: true, TeamName: "backend"})
+	}
+
+	pr := &api.PullRequest{PullRequestId: "pr-1", PullRequestName: "Test PR", AuthorId: "u1"}
+	if err := service.CreatePR(pr, CreatePROptions{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	first := pr.AssignedReviewers[0]
+	reassigned := created.Add(time.Hour)
+	clock.now = reassigned
+	_, second, err := service.ReassignReviewer("pr-1", first)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	history, err := service.GetHistory("pr-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("Expected 2 history entries, got %+v", history)
+	}
+	if history[0].UserId != first || history[0].Action != api.ReviewerAssignmentActionCreate || history[0].UnassignedAt == nil {
+		t.Errorf("Expected closed create entry for %s, got %+v", first, history[0])
+	}
+	if history[1].UserId != *second || history[1].Action != api.ReviewerAssignmentActionReassign ||
+		history[1].ReplacedUserId == nil || *history[1].ReplacedUserId != first || history[1].UnassignedAt != nil {
+		t.Errorf("Expected open reassign entry replacing %s, got %+v", first, history[1])
+	}
+	if !history[0].AssignedAt.Equal(created) || history[0].UnassignedAt == nil ||
+		!history[0].UnassignedAt.Equal(reassigned) || !history[1].AssignedAt.Equal(reassigned) {
+		t.Errorf("Expected history stamped by the service clock, got %+v", history)
+	}
+
+	if _, err := service.GetHistory("pr-404"); !errors.Is(err, domain.ErrPRNotFound) {
+		t.Errorf("Expected 'PR not found' error, got %v", err)
+	}
+}
+
+func TestHistoryLogsUnrecordedReviewersAsManual(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	service := NewPullRequestService(prRepo, inmemory.NewTeamRepository(), inmemory.NewUserRepository())
+
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2"},
+	})
+
+	history, _ := service.GetHistory("pr-1")
+	if len(history) != 1 || history[0].Action != api.ReviewerAssignmentActionManual {
+		t.Errorf("Expected one manual entry, got %+v", history)
+	}
+}
+
+func TestUpdatePRRejectsStaleVersion(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2"},
+	})
+
+	first, _ := prRepo.FindPRByID("pr-1")
+	second, _ := prRepo.FindPRByID("pr-1")
+	first.AssignedReviewers = []string{"u3"}
+	if err := prRepo.UpdatePR(*first); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second.AssignedReviewers = []string{"u4"}
+	if err := prRepo.UpdatePR(*second); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("Expected version conflict, got %v", err)
+	}
+
+	stored, _ := prRepo.FindPRByID("pr-1")
+	if stored.Version != 1 || stored.AssignedReviewers[0] != "u3" {
+		t.Errorf("Expected first update at version 1, got %+v", stored)
+	}
+}
+
+func TestConcurrentReassignmentsAreNotLost(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepository()
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+
+	var members []api.TeamMember
+	for i := 1; i <= 8; i++ {
+		member := api.TeamMember{UserId: fmt.Sprintf("u%d", i), Username: fmt.Sprintf("User %d", i), IsActive: true}
+		members = append(members, member)
+		userRepo.AddUser(&api.User{UserId: member.UserId, Username: member.Username, IsActive: true, TeamName: "backend"})
+	}
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", Members: members})
+
+	for i := 0; i < 50; i++ {
+		prID := fmt.Sprintf("pr-%d", i)
+		_ = prRepo.CreatePR(api.PullRequest{
+			PullRequestId:     prID,
+			AuthorId:          "u1",
+			Status:            api.PullRequestStatusOPEN,
+			AssignedReviewers: []string{"u2", "u3"},
+		})
+
+		var wg sync.WaitGroup
+		for _, oldReviewer := range []string{"u2", "u3"} {
+			wg.Add(1)
+			go func(oldReviewer string) {
+				defer wg.Done()
+				if _, _, err := service.ReassignReviewer(prID, oldReviewer); err != nil {
+					t.Errorf("Expected no error reassigning %s on %s, got %v", oldReviewer, prID, err)
+				}
+			}(oldReviewer)
+		}
+		wg.Wait()
+
+		pr, _ := prRepo.FindPRByID(prID)
+		if pr.Version != 2 {
+			t.Fatalf("Expected both reassignments to be applied on %s, got version %d", prID, pr.Version)
+		}
+		if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] == pr.AssignedReviewers[1] {
+			t.Fatalf("Expected 2 distinct reviewers on %s, got %v", prID, pr.AssignedReviewers)
+		}
+		history, _ := service.GetHistory(prID)
+		reassigned := 0
+		for _, entry := range history {
+			if entry.Action == api.ReviewerAssignmentActionReassign {
+				reassigned++
+			}
+		}
+		if reassigned != 2 {
+			t.Fatalf("Expected 2 reassign entries on %s, got %+v", prID, history)
+		}
+	}
+}
+
+func TestCapacityLimitsOverflowOrQueue(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepositoryWithUsers(userRepo)
+	overflow := NewPullRequestService(prRepo, teamRepo, userRepo)
+	queue := NewPullRequestService(prRepo, teamRepo, userRepo,
+		WithCapacityConfig(config.CapacityConfig{WhenFull: "queue"}))
+
+	maxOpenReviews := 1
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", MaxOpenReviews: &maxOpenReviews, Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+		{UserId: "u4", Username: "Diana", IsActive: true},
+	}})
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		TeamName:          "backend",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2", "u3"},
+	})
+
+	pr2 := &api.PullRequest{PullRequestId: "pr-2", PullRequestName: "Add cache", AuthorId: "u1"}
+	if err := overflow.CreatePR(pr2, CreatePROptions{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pr2.AssignedReviewers) != 2 || pr2.AssignedReviewers[0] != "u4" {
+		t.Fatalf("Expected u4 under capacity first, got %v", pr2.AssignedReviewers)
+	}
+	if pr2.OverflowReviewers == nil || len(*pr2.OverflowReviewers) != 1 ||
+		(*pr2.OverflowReviewers)[0] != pr2.AssignedReviewers[1] || pr2.IsQueued {
+		t.Errorf("Expected the second reviewer over capacity, got %+v", pr2)
+	}
+
+	pr3 := &api.PullRequest{PullRequestId: "pr-3", PullRequestName: "Drop cache", AuthorId: "u1"}
+	if err := queue.CreatePR(pr3, CreatePROptions{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !pr3.IsQueued || len(pr3.AssignedReviewers) != 0 {
+		t.Fatalf("Expected pr-3 queued without reviewers, got %+v", pr3)
+	}
+
+	if _, err := queue.MergePR("pr-1", true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	pr3, _ = queue.FindPRByID("pr-3")
+	if len(pr3.AssignedReviewers) != 1 || containsReviewer(*pr3, pr2.AssignedReviewers[1]) || !pr3.IsQueued {
+		t.Errorf("Expected the freed reviewer assigned and pr-3 still queued, got %+v", pr3)
+	}
+	history, _ := queue.GetHistory("pr-3")
+	if len(history) != 1 || history[0].Action != api.ReviewerAssignmentActionQueue {
+		t.Errorf("Expected one queue assignment, got %+v", history)
+	}
+
+	if _, newReviewer, err := queue.ReassignReviewer("pr-2", "u4"); err != nil || newReviewer != nil {
+		t.Errorf("Expected u4 released with the PR queued, got %v %v", newReviewer, err)
+	}
+}
+
+func TestCreatePRPrefersExpertsMatchingLabels(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepositoryWithUsers(userRepo)
+	service := NewPullRequestService(prRepo, teamRepo, userRepo,
+		WithSelectionConfig(config.SelectionConfig{RequireExpert: true}))
+	userService := NewUserService(userRepo, teamRepo)
+
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "frontend", Members: []api.TeamMember{
+		{UserId: "u5", Username: "Eve", IsActive: true},
+		{UserId: "u6", Username: "Frank", IsActive: true},
+	}})
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "mobile", Members: []api.TeamMember{
+		{UserId: "u7", Username: "Grace", IsActive: true},
+	}})
+	fallbackTeams := []string{"frontend"}
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", FallbackTeams: &fallbackTeams, Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+		{UserId: "u4", Username: "Diana", IsActive: true},
+	}})
+
+	tags := []string{" DB ", "payments", "db"}
+	user, _, err := userService.UpdateUser(api.PostUsersUpdateJSONRequestBody{UserId: "u3", Tags: &tags})
+	if err != nil || user.Tags == nil || fmt.Sprint(*user.Tags) != "[db payments]" {
+		t.Fatalf("Expected normalized tags, got %+v %v", user, err)
+	}
+	if _, _, err := userService.UpdateUser(api.PostUsersUpdateJSONRequestBody{UserId: "u4", Tags: &[]string{" "}}); !errors.Is(err, domain.ErrInvalidTags) {
+		t.Errorf("Expected invalid tags error, got %v", err)
+	}
+	_, _, _ = userService.UpdateUser(api.PostUsersUpdateJSONRequestBody{UserId: "u5", Tags: &[]string{"frontend"}})
+	_, _, _ = userService.UpdateUser(api.PostUsersUpdateJSONRequestBody{UserId: "u7", Tags: &[]string{"ios"}})
+	if users, _ := userService.ListUsers("", "DB", nil); len(users) != 1 || users[0].UserId != "u3" {
+		t.Errorf("Expected u3 listed by tag, got %+v", users)
+	}
+
+	for i := 0; i < 10; i++ {
+		pr := &api.PullRequest{PullRequestId: fmt.Sprintf("pr-db-%d", i), PullRequestName: "Index orders", AuthorId: "u1"}
+		if err := service.CreatePR(pr, CreatePROptions{Labels: []string{"DB"}}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u3" {
+			t.Fatalf("Expected the db expert picked first, got %v", pr.AssignedReviewers)
+		}
+	}
+
+	pr := &api.PullRequest{PullRequestId: "pr-ui", PullRequestName: "New button", AuthorId: "u1"}
+	if err := service.CreatePR(pr, CreatePROptions{Labels: []string{"frontend"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u5" {
+		t.Errorf("Expected the frontend expert from the fallback team, got %v", pr.AssignedReviewers)
+	}
+	if pr.FallbackReviewers == nil || fmt.Sprint(*pr.FallbackReviewers) != "[u5]" {
+		t.Errorf("Expected u5 recorded as a fallback reviewer, got %v", pr.FallbackReviewers)
+	}
+
+	pr = &api.PullRequest{PullRequestId: "pr-ios", PullRequestName: "Push tokens", AuthorId: "u1"}
+	if err := service.CreatePR(pr, CreatePROptions{Labels: []string{"ios"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if containsReviewer(*pr, "u7") || pr.FallbackReviewers != nil {
+		t.Errorf("Expected no expert from an unrelated team, got %v", pr.AssignedReviewers)
+	}
+}
+
+func TestSeniorityPolicyOnCreateAndReassign(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepositoryWithUsers(userRepo)
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+	userService := NewUserService(userRepo, teamRepo)
+
+	level := func(seniority api.Seniority) *api.Seniority { return &seniority }
+	minSeniors := 1
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", MinSeniorReviewers: &minSeniors, Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true, Seniority: level(api.SenioritySenior)},
+		{UserId: "u3", Username: "Charlie", IsActive: true, Seniority: level(api.SeniorityJunior)},
+		{UserId: "u4", Username: "Diana", IsActive: true, Seniority: level(api.SeniorityMiddle)},
+		{UserId: "u5", Username: "Eve", IsActive: true, Seniority: level(api.SeniorityLead)},
+	}})
+	if _, err := userService.CreateUser(api.User{UserId: "u6", Username: "Frank", Seniority: level("principal")}); !errors.Is(err, domain.ErrInvalidSeniority) {
+		t.Errorf("Expected invalid seniority error, got %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		pr := &api.PullRequest{PullRequestId: fmt.Sprintf("pr-%d", i), PullRequestName: "Add search", AuthorId: "u1"}
+		if err := service.CreatePR(pr, CreatePROptions{}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !containsReviewer(*pr, "u2") && !containsReviewer(*pr, "u5") {
+			t.Fatalf("Expected a senior reviewer, got %v", pr.AssignedReviewers)
+		}
+	}
+
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId: "pr-senior", AuthorId: "u1", TeamName: "backend",
+		Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2", "u3"},
+	})
+	if _, newReviewer, err := service.ReassignReviewer("pr-senior", "u2"); err != nil || *newReviewer != "u5" {
+		t.Fatalf("Expected the senior replaced by u5, got %v %v", newReviewer, err)
+	}
+
+	_ = userRepo.UpdateUserStatus("u2", false)
+	if _, newReviewer, err := service.ReassignReviewer("pr-senior", "u3"); err != nil || *newReviewer != "u4" {
+		t.Errorf("Expected a middle reviewer once the policy holds, got %v %v", newReviewer, err)
+	}
+	if _, _, err := service.ReassignReviewer("pr-senior", "u5"); !errors.Is(err, domain.ErrNoSeniorCandidate) {
+		t.Errorf("Expected no senior candidate, got %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
+)
+
+// SelectionRequest describes a single reviewer selection: which team the
+// candidates come from, whose PR they will review, who may be picked and how
+// many reviewers are needed.
+type SelectionRequest struct {
+	TeamName   string
+	AuthorID   string
+	Candidates []api.TeamMember
+	Count      int
+}
+
+// ReviewerSelector picks reviewers out of the candidates of a SelectionRequest.
+type ReviewerSelector interface {
+	SelectReviewers(req SelectionRequest) ([]string, error)
+}
+
+func randomIndex(n int) (int, error) {
+	maxN := big.NewInt(int64(n))
+	num, err := rand.Int(rand.Reader, maxN)
+	if err != nil {
+		return 0, err
+	}
+	return int(num.Int64()), nil
+}
+
+// shuffleMembers returns a randomly ordered copy of members, so that a stable
+// sort over it breaks ties randomly.
+func shuffleMembers(members []api.TeamMember) ([]api.TeamMember, error) {
+	shuffled := make([]api.TeamMember, len(members))
+	copy(shuffled, members)
+	for i := len(shuffled) - 1; i > 0; i-- {
+		j, err := randomIndex(i + 1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to shuffle candidates: %w", err)
+		}
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+	return shuffled, nil
+}
+
+func selectionCount(req SelectionRequest) int {
+	count := req.Count
+	if count > len(req.Candidates) {
+		count = len(req.Candidates)
+	}
+	if count < 0 {
+		count = 0
+	}
+	return count
+}
+
+func validSelectionStrategy(strategy api.TeamSelectionStrategy) bool {
+	switch strategy {
+	case api.TeamSelectionStrategyRandom, api.TeamSelectionStrategyRoundRobin, api.TeamSelectionStrategyLeastLoaded,
+		api.TeamSelectionStrategyHistoryAware:
+		return true
+	}
+	return false
+}
+
+type RandomSelector struct{}
+
+func NewRandomSelector() *RandomSelector {
+	return &RandomSelector{}
+}
+
+func (s *RandomSelector) SelectReviewers(req SelectionRequest) ([]string, error) {
+	count := selectionCount(req)
+	reviewers := make([]string, 0, count)
+	used := make(map[int]bool)
+
+	for len(reviewers) < count {
+		idx, err := randomIndex(len(req.Candidates))
+		if err != nil {
+			return nil, fmt.Errorf("failed to pick random reviewer: %w", err)
+		}
+		if !used[idx] {
+			reviewers = append(reviewers, req.Candidates[idx].UserId)
+			used[idx] = true
+		}
+	}
+	return reviewers, nil
+}
+
+// RoundRobinSelector walks the team members in user_id order and continues
+// after the reviewer it picked last time for the same team.
+type RoundRobinSelector struct {
+	mu   sync.Mutex
+	last map[string]string
+}
+
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{
+		last: make(map[string]string),
+	}
+}
+
+func (s *RoundRobinSelector) SelectReviewers(req SelectionRequest) ([]string, error) {
+	count := selectionCount(req)
+	if count == 0 {
+		return []string{}, nil
+	}
+
+	candidates := make([]api.TeamMember, len(req.Candidates))
+	copy(candidates, req.Candidates)
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].UserId < candidates[j].UserId
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := 0
+	if last, ok := s.last[req.TeamName]; ok {
+		start = sort.Search(len(candidates), func(i int) bool {
+			return candidates[i].UserId > last
+		}) % len(candidates)
+	}
+
+	reviewers := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		reviewers = append(reviewers, candidates[(start+i)%len(candidates)].UserId)
+	}
+	s.last[req.TeamName] = reviewers[len(reviewers)-1]
+
+	return reviewers, nil
+}
+
+// LeastLoadedSelector prefers candidates with the fewest OPEN pull requests
+// to review. Candidates with the same load are ordered randomly.
+type LeastLoadedSelector struct {
+	pullRequestRepository repository.PullRequestRepository
+}
+
+func NewLeastLoadedSelector(pullRequestRepository repository.PullRequestRepository) *LeastLoadedSelector {
+	return &LeastLoadedSelector{
+		pullRequestRepository: pullRequestRepository,
+	}
+}
+
+func (s *LeastLoadedSelector) SelectReviewers(req SelectionRequest) ([]string, error) {
+	count := selectionCount(req)
+	if count == 0 {
+		return []string{}, nil
+	}
+
+	userIDs := make([]string, 0, len(req.Candidates))
+	for _, candidate := range req.Candidates {
+		userIDs = append(userIDs, candidate.UserId)
+	}
+	load, err := s.pullRequestRepository.CountOpenReviewsByUsers(userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer load: %w", err)
+	}
+
+	candidates, err := shuffleMembers(req.Candidates)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return load[candidates[i].UserId] < load[candidates[j].UserId]
+	})
+
+	reviewers := make([]string, 0, count)
+	for _, candidate := range candidates[:count] {
+		reviewers = append(reviewers, candidate.UserId)
+	}
+	return reviewers, nil
+}
+
+// HistoryAwareSelector prefers candidates who were assigned to the fewest PRs
+// of the same author within the look-back window, so that author/reviewer
+// pairs rotate. Candidates with the same count are ordered randomly.
+type HistoryAwareSelector struct {
+	pullRequestRepository repository.PullRequestRepository
+	window                time.Duration
+	clock                 Clock
+}
+
+func NewHistoryAwareSelector(pullRequestRepository repository.PullRequestRepository, window time.Duration, clock Clock) *HistoryAwareSelector {
+	return &HistoryAwareSelector{
+		pullRequestRepository: pullRequestRepository,
+		window:                window,
+		clock:                 clock,
+	}
+}
+
+func (s *HistoryAwareSelector) SelectReviewers(req SelectionRequest) ([]string, error) {
+	count := selectionCount(req)
+	if count == 0 {
+		return []string{}, nil
+	}
+
+	userIDs := make([]string, 0, len(req.Candidates))
+	for _, candidate := range req.Candidates {
+		userIDs = append(userIDs, candidate.UserId)
+	}
+	pairings, err := s.pullRequestRepository.CountRecentPairings(req.AuthorID, userIDs, s.clock.Now().Add(-s.window))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review history: %w", err)
+	}
+
+	candidates, err := shuffleMembers(req.Candidates)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return pairings[candidates[i].UserId] < pairings[candidates[j].UserId]
+	})
+
+	reviewers := make([]string, 0, count)
+	for _, candidate := range candidates[:count] {
+		reviewers = append(reviewers, candidate.UserId)
+	}
+	return reviewers, nil
+}
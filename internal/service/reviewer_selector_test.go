@@ -0,0 +1,228 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository/inmemory"
+)
+
+func TestRandomSelector(t *testing.T) {
+	selector := NewRandomSelector()
+	members := []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+	}
+
+	reviewers, err := selector.SelectReviewers(SelectionRequest{TeamName: "backend", Candidates: members, Count: 3})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(reviewers) != 3 {
+		t.Fatalf("Expected 3 reviewers, got %d", len(reviewers))
+	}
+
+	seen := make(map[string]bool)
+	for _, reviewer := range reviewers {
+		if seen[reviewer] {
+			t.Errorf("Reviewer %s selected twice", reviewer)
+		}
+		seen[reviewer] = true
+	}
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	selector := NewRoundRobinSelector()
+	members := []api.TeamMember{
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+	}
+
+	expected := [][]string{{"u1", "u2"}, {"u3", "u1"}, {"u2", "u3"}}
+	for i, want := range expected {
+		reviewers, err := selector.SelectReviewers(SelectionRequest{TeamName: "backend", Candidates: members, Count: 2})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(reviewers) != 2 || reviewers[0] != want[0] || reviewers[1] != want[1] {
+			t.Errorf("Round %d: expected %v, got %v", i, want, reviewers)
+		}
+	}
+
+	reviewers, _ := selector.SelectReviewers(SelectionRequest{TeamName: "frontend", Candidates: members, Count: 1})
+	if len(reviewers) != 1 || reviewers[0] != "u1" {
+		t.Errorf("Expected other team to start from u1, got %v", reviewers)
+	}
+}
+
+func TestLeastLoadedSelector(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId: "pr-1", AuthorId: "u9", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u1", "u2"},
+	})
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId: "pr-2", AuthorId: "u9", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u1"},
+	})
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId: "pr-3", AuthorId: "u9", Status: api.PullRequestStatusMERGED, AssignedReviewers: []string{"u3"},
+	})
+
+	selector := NewLeastLoadedSelector(prRepo)
+	members := []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+	}
+
+	reviewers, err := selector.SelectReviewers(SelectionRequest{TeamName: "backend", Candidates: members, Count: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(reviewers) != 2 || reviewers[0] != "u3" || reviewers[1] != "u2" {
+		t.Errorf("Expected [u3 u2], got %v", reviewers)
+	}
+}
+
+func TestCreatePRUsesTeamStrategy(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepository()
+
+	strategy := api.TeamSelectionStrategyRoundRobin
+	_ = teamRepo.CreateTeam(api.Team{
+		TeamName:          "backend",
+		SelectionStrategy: &strategy,
+		Members: []api.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+			{UserId: "u3", Username: "Charlie", IsActive: true},
+			{UserId: "u4", Username: "Diana", IsActive: true},
+		},
+	})
+	userRepo.AddUser(&api.User{UserId: "u1", Username: "Alice", IsActive: true, TeamName: "backend"})
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+
+	pr := &api.PullRequest{PullRequestId: "pr-1", PullRequestName: "First", AuthorId: "u1"}
+	if err := service.CreatePR(pr, CreatePROptions{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u2" || pr.AssignedReviewers[1] != "u3" {
+		t.Errorf("Expected [u2 u3], got %v", pr.AssignedReviewers)
+	}
+
+	pr = &api.PullRequest{PullRequestId: "pr-2", PullRequestName: "Second", AuthorId: "u1"}
+	if err := service.CreatePR(pr, CreatePROptions{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u4" || pr.AssignedReviewers[1] != "u2" {
+		t.Errorf("Expected [u4 u2], got %v", pr.AssignedReviewers)
+	}
+}
+
+func TestLeastLoadedSelectorBreaksTiesRandomly(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId: "pr-1", AuthorId: "u9", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u3"},
+	})
+
+	selector := NewLeastLoadedSelector(prRepo)
+	members := []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+	}
+
+	picked := make(map[string]int)
+	for i := 0; i < 200; i++ {
+		reviewers, err := selector.SelectReviewers(SelectionRequest{TeamName: "backend", Candidates: members, Count: 1})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		picked[reviewers[0]]++
+	}
+
+	if picked["u3"] != 0 {
+		t.Errorf("Expected loaded reviewer u3 never to be picked, got %d", picked["u3"])
+	}
+	if picked["u1"] == 0 || picked["u2"] == 0 {
+		t.Errorf("Expected ties between u1 and u2 to be broken randomly, got %v", picked)
+	}
+}
+
+func TestHistoryAwareSelector(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	prRepo := inmemory.NewPullRequestRepository()
+	seed := func(prID, authorID, reviewerID string, assignedAt time.Time) {
+		_ = prRepo.CreatePR(api.PullRequest{
+			PullRequestId: prID, AuthorId: authorID, Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{reviewerID},
+		}, repository.AssignmentRecord{
+			PullRequestId: prID, UserId: reviewerID, Action: api.ReviewerAssignmentActionCreate, AssignedAt: assignedAt,
+		})
+	}
+	seed("pr-1", "u1", "u2", now.Add(-2*time.Hour))
+	seed("pr-2", "u1", "u3", now.Add(-48*time.Hour))
+	seed("pr-3", "u9", "u4", now.Add(-time.Hour))
+
+	selector := NewHistoryAwareSelector(prRepo, 24*time.Hour, &fakeClock{now: now})
+	members := []api.TeamMember{
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+		{UserId: "u4", Username: "Diana", IsActive: true},
+	}
+
+	for i := 0; i < 20; i++ {
+		reviewers, err := selector.SelectReviewers(SelectionRequest{TeamName: "backend", AuthorID: "u1", Candidates: members, Count: 2})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(reviewers) != 2 || reviewers[0] == "u2" || reviewers[1] == "u2" {
+			t.Fatalf("Expected u2, who recently reviewed u1, to be skipped, got %v", reviewers)
+		}
+	}
+
+	reviewers, err := selector.SelectReviewers(SelectionRequest{TeamName: "backend", AuthorID: "u1", Candidates: members, Count: 3})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(reviewers) != 3 || reviewers[2] != "u2" {
+		t.Errorf("Expected u2 to be picked last, got %v", reviewers)
+	}
+}
+
+func TestCreatePRRotatesHistoryAwareReviewers(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepository()
+
+	strategy := api.TeamSelectionStrategyHistoryAware
+	required := 1
+	_ = teamRepo.CreateTeam(api.Team{
+		TeamName:          "backend",
+		SelectionStrategy: &strategy,
+		RequiredReviewers: &required,
+		Members: []api.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+			{UserId: "u3", Username: "Charlie", IsActive: true},
+			{UserId: "u4", Username: "Diana", IsActive: true},
+		},
+	})
+	userRepo.AddUser(&api.User{UserId: "u1", Username: "Alice", IsActive: true, TeamName: "backend"})
+	service := NewPullRequestService(prRepo, teamRepo, userRepo)
+
+	seen := make(map[string]bool)
+	for _, prID := range []string{"pr-1", "pr-2", "pr-3"} {
+		pr := &api.PullRequest{PullRequestId: prID, PullRequestName: prID, AuthorId: "u1"}
+		if err := service.CreatePR(pr, CreatePROptions{}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(pr.AssignedReviewers) != 1 || seen[pr.AssignedReviewers[0]] {
+			t.Fatalf("Expected a reviewer who has not reviewed u1 yet, got %v after %v", pr.AssignedReviewers, seen)
+		}
+		seen[pr.AssignedReviewers[0]] = true
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+)
+
+// seniorityRanks orders the seniority levels from junior to lead.
+var seniorityRanks = map[api.Seniority]int{
+	api.SeniorityJunior: 1,
+	api.SeniorityMiddle: 2,
+	api.SenioritySenior: 3,
+	api.SeniorityLead:   4,
+}
+
+func validSeniority(level api.Seniority) bool {
+	_, ok := seniorityRanks[level]
+	return ok
+}
+
+// isSenior reports whether level is senior or above. Users without a level
+// are not senior.
+func isSenior(level *api.Seniority) bool {
+	return level != nil && seniorityRanks[*level] >= seniorityRanks[api.SenioritySenior]
+}
+
+// missingSeniors returns how many more senior reviewers a PR of teamName with
+// reviewers needs to meet the team's min_senior_reviewers, which never asks
+// for more than required_reviewers.
+func (s *PullRequestService) missingSeniors(teamName string, reviewers []string) (int, error) {
+	team := s.teamRepository.FindTeamByName(teamName)
+	if team.MinSeniorReviewers == nil {
+		return 0, nil
+	}
+	missing := min(*team.MinSeniorReviewers, s.requiredReviewers(teamName))
+	for _, reviewerID := range reviewers {
+		reviewer, err := s.userRepository.FindUserByID(reviewerID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return 0, err
+		}
+		if reviewer != nil && isSenior(reviewer.Seniority) {
+			missing--
+		}
+	}
+	return max(missing, 0), nil
+}
+
+// seniorOnly returns a copy of excluded that also holds every active member
+// below senior of teamName and its fallback teams, so that selection from
+// them only yields seniors.
+func (s *PullRequestService) seniorOnly(teamName string, excluded map[string]bool) (map[string]bool, error) {
+	result := make(map[string]bool, len(excluded))
+	for userID := range excluded {
+		result[userID] = true
+	}
+	teams := []string{teamName}
+	if team := s.teamRepository.FindTeamByName(teamName); team.FallbackTeams != nil {
+		teams = append(teams, *team.FallbackTeams...)
+	}
+	for _, name := range teams {
+		members, err := s.activeTeamMembers(name, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			if !isSenior(member.Seniority) {
+				result[member.UserId] = true
+			}
+		}
+	}
+	return result, nil
+}
+
+// pickSeniors selects the senior reviewers a new PR of teamName still needs
+// besides reviewers: from teamName first, then from its fallback teams.
+// Selected users are added to picked. The second result lists the seniors
+// taken from fallback teams.
+func (s *PullRequestService) pickSeniors(teamName string, authorID string, reviewers []string, picked map[string]bool) ([]string, []string, error) {
+	missing, err := s.missingSeniors(teamName, reviewers)
+	if err != nil || missing == 0 {
+		return nil, nil, err
+	}
+
+	excluded := map[string]bool{authorID: true}
+	for userID := range picked {
+		excluded[userID] = true
+	}
+	excluded, err = s.seniorOnly(teamName, excluded)
+	if err != nil {
+		return nil, nil, err
+	}
+	members, err := s.activeTeamMembers(teamName, authorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var candidates []api.TeamMember
+	for _, member := range members {
+		if !excluded[member.UserId] {
+			candidates = append(candidates, member)
+		}
+	}
+	seniors, err := s.selectReviewers(teamName, authorID, candidates, missing)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, senior := range seniors {
+		excluded[senior] = true
+	}
+	fallback, err := s.fallbackReviewers(teamName, authorID, excluded, missing-len(seniors))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seniors = append(seniors, fallback...)
+	for _, senior := range seniors {
+		picked[senior] = true
+	}
+	return seniors, fallback, nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/config"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
+)
+
+const (
+	defaultSLACheckInterval = time.Minute
+	defaultReviewTimeout    = 24 * time.Hour
+)
+
+// SLAScheduler escalates reviewers that do not act on an open PR in time.
+// A reviewer that has not submitted a review within the SLA of the PR's team
+// gets a reminder first; if another SLA passes after the reminder without a
+// review, the reviewer is reassigned the same way ReassignReviewer does it.
+type SLAScheduler struct {
+	prService *PullRequestService
+	interval  time.Duration
+	timeout   time.Duration
+}
+
+func NewSLAScheduler(prService *PullRequestService, cfg config.SLAConfig) *SLAScheduler {
+	interval := cfg.CheckInterval
+	if interval <= 0 {
+		interval = defaultSLACheckInterval
+	}
+	timeout := cfg.ReviewTimeout
+	if timeout <= 0 {
+		timeout = defaultReviewTimeout
+	}
+	return &SLAScheduler{
+		prService: prService,
+		interval:  interval,
+		timeout:   timeout,
+	}
+}
+
+// Run checks for overdue reviewers every interval until ctx is done.
+func (s *SLAScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.CheckOverdue(); err != nil {
+			slog.Error("Error checking review SLA", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// timeoutFor returns the review_timeout_minutes of a team, falling back to
+// the default SLA.
+func (s *SLAScheduler) timeoutFor(teamName string) time.Duration {
+	team := s.prService.teamRepository.FindTeamByName(teamName)
+	if team.ReviewTimeoutMinutes != nil {
+		return time.Duration(*team.ReviewTimeoutMinutes) * time.Minute
+	}
+	return s.timeout
+}
+
+// CheckOverdue escalates every overdue reviewer of the open PRs once and
+// returns how many escalations were made. A PR that fails to escalate is
+// logged and skipped so that it does not hold up the others.
+func (s *SLAScheduler) CheckOverdue() (int, error) {
+	prs, err := s.prService.pullRequestRepository.ListPRs(repository.PRFilter{Status: api.PullRequestStatusOPEN})
+	if err != nil {
+		return 0, err
+	}
+
+	escalated := 0
+	for _, pr := range prs {
+		if pr.IsDraft || len(pr.AssignedReviewers) == 0 {
+			continue
+		}
+		count, err := s.checkPR(pr)
+		escalated += count
+		if err != nil {
+			slog.Warn("Skipping SLA escalation", "pull_request_id", pr.PullRequestId, "error", err)
+		}
+	}
+	return escalated, nil
+}
+
+// checkPR escalates the overdue reviewers of pr. A reviewer's SLA starts when
+// they were assigned; a review submitted after that stops it.
+func (s *SLAScheduler) checkPR(pr api.PullRequest) (int, error) {
+	repo := s.prService.pullRequestRepository
+	assignments, err := repo.FindAssignmentsByPR(pr.PullRequestId)
+	if err != nil {
+		return 0, err
+	}
+	reviews, err := repo.FindReviewsByPR(pr.PullRequestId)
+	if err != nil {
+		return 0, err
+	}
+	escalations, err := repo.FindEscalationsByPR(pr.PullRequestId)
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.prService.clock.Now()
+	timeout := s.timeoutFor(pr.TeamName)
+	escalated := 0
+	for _, reviewerID := range pr.AssignedReviewers {
+		var start time.Time
+		if pr.CreatedAt != nil {
+			start = *pr.CreatedAt
+		}
+		for _, assignment := range assignments {
+			if assignment.UserId == reviewerID && assignment.UnassignedAt == nil {
+				start = assignment.AssignedAt
+			}
+		}
+		if reviewedSince(reviews, reviewerID, start) {
+			continue
+		}
+
+		reminder := lastReminderSince(escalations, reviewerID, start)
+		switch {
+		case reminder == nil && now.Sub(start) >= timeout:
+			if err := s.prService.remindReviewer(pr.PullRequestId, reviewerID); err != nil {
+				return escalated, err
+			}
+			escalated++
+		case reminder != nil && now.Sub(reminder.EscalatedAt) >= timeout:
+			newReviewer, err := s.prService.reassignOverdueReviewer(pr.PullRequestId, reviewerID)
+			if err != nil {
+				return escalated, err
+			}
+			slog.Info("Reassigned overdue reviewer", "pull_request_id", pr.PullRequestId,
+				"old_reviewer", reviewerID, "new_reviewer", valueOrEmpty(newReviewer))
+			escalated++
+		}
+	}
+	return escalated, nil
+}
+
+func reviewedSince(reviews []api.ReviewDecision, userID string, since time.Time) bool {
+	for _, review := range reviews {
+		if review.UserId == userID && !review.SubmittedAt.Before(since) {
+			return true
+		}
+	}
+	return false
+}
+
+func lastReminderSince(escalations []api.ReviewEscalation, userID string, since time.Time) *api.ReviewEscalation {
+	var last *api.ReviewEscalation
+	for i := range escalations {
+		escalation := &escalations[i]
+		if escalation.UserId == userID && escalation.Stage == api.ReviewEscalationStageReminder &&
+			!escalation.EscalatedAt.Before(since) {
+			last = escalation
+		}
+	}
+	return last
+}
+
+func valueOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
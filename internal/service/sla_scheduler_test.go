@@ -0,0 +1,114 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/config"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository/inmemory"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestSLASchedulerRemindsThenReassigns(t *testing.T) {
+	prRepo := inmemory.NewPullRequestRepository()
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepositoryWithUsers(userRepo)
+	clock := &fakeClock{now: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)}
+	service := NewPullRequestService(prRepo, teamRepo, userRepo, WithClock(clock))
+	scheduler := NewSLAScheduler(service, config.SLAConfig{ReviewTimeout: 24 * time.Hour})
+
+	reviewTimeout := 120
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", ReviewTimeoutMinutes: &reviewTimeout, Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+		{UserId: "u4", Username: "Diana", IsActive: true},
+	}})
+	createdAt := clock.now
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		TeamName:          "backend",
+		Status:            api.PullRequestStatusOPEN,
+		CreatedAt:         &createdAt,
+		AssignedReviewers: []string{"u2", "u3"},
+	}, repository.AssignmentRecord{
+		PullRequestId: "pr-1", UserId: "u2", Action: api.ReviewerAssignmentActionCreate, AssignedAt: createdAt,
+	}, repository.AssignmentRecord{
+		PullRequestId: "pr-1", UserId: "u3", Action: api.ReviewerAssignmentActionCreate, AssignedAt: createdAt,
+	})
+
+	clock.now = createdAt.Add(time.Hour)
+	if escalated, _ := scheduler.CheckOverdue(); escalated != 0 {
+		t.Fatalf("Expected no escalation within the team SLA, got %d", escalated)
+	}
+
+	_, _, _ = service.SubmitReview("pr-1", "u3", api.ReviewDecisionDecisionApproved)
+	clock.now = createdAt.Add(2 * time.Hour)
+	if escalated, _ := scheduler.CheckOverdue(); escalated != 1 {
+		t.Fatalf("Expected a reminder for u2 only, got %d escalations", escalated)
+	}
+	if escalated, _ := scheduler.CheckOverdue(); escalated != 0 {
+		t.Fatalf("Expected the reminder not to repeat, got %d escalations", escalated)
+	}
+
+	clock.now = createdAt.Add(4 * time.Hour)
+	if escalated, _ := scheduler.CheckOverdue(); escalated != 1 {
+		t.Fatalf("Expected u2 to be reassigned, got %d escalations", escalated)
+	}
+	pr, _ := service.FindPRByID("pr-1")
+	if containsReviewer(*pr, "u2") || !containsReviewer(*pr, "u4") {
+		t.Errorf("Expected u2 replaced by u4, got %v", pr.AssignedReviewers)
+	}
+
+	escalations, err := service.GetEscalations("pr-1")
+	if err != nil || len(escalations) != 2 {
+		t.Fatalf("Expected two escalations, got %+v %v", escalations, err)
+	}
+	reminder, reassignment := escalations[0], escalations[1]
+	if reminder.Stage != api.ReviewEscalationStageReminder || reminder.UserId != "u2" ||
+		!reminder.EscalatedAt.Equal(createdAt.Add(2*time.Hour)) {
+		t.Errorf("Unexpected reminder %+v", reminder)
+	}
+	if reassignment.Stage != api.ReviewEscalationStageReassignment || reassignment.UserId != "u2" ||
+		reassignment.NewUserId == nil || *reassignment.NewUserId != "u4" {
+		t.Errorf("Unexpected reassignment %+v", reassignment)
+	}
+
+	history, _ := service.GetHistory("pr-1")
+	last := history[len(history)-1]
+	if last.UserId != "u4" || !last.AssignedAt.Equal(clock.now) {
+		t.Errorf("Expected u4 assigned at the clock time, got %+v", last)
+	}
+
+	pending, _ := prRepo.FindPendingEvents(10)
+	reminders := 0
+	for _, stored := range pending {
+		var event Event
+		_ = json.Unmarshal(stored.Payload, &event)
+		if event.Type == EventReviewReminder {
+			reminders++
+			if event.OverdueReviewer != "u2" || !event.OccurredAt.Equal(createdAt.Add(2*time.Hour)) {
+				t.Errorf("Expected reminder for u2 at the clock time, got %+v", event)
+			}
+		}
+	}
+	if reminders != 1 {
+		t.Errorf("Expected one reminder event, got %d", reminders)
+	}
+
+	clock.now = createdAt.Add(6 * time.Hour)
+	if escalated, _ := scheduler.CheckOverdue(); escalated != 1 {
+		t.Errorf("Expected the SLA of u4 to start at reassignment, got %d escalations", escalated)
+	}
+}
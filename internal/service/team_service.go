@@ -1,36 +1,266 @@
 package service
 
 import (
-	"fmt"
-
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
 )
 
 type TeamService struct {
 	teamRepository repository.TeamRepository
+	reviewReleaser ReviewReleaser
+}
+
+// ReviewReleaser hands the open reviews of users leaving a team over to
+// other reviewers. PullRequestService implements it.
+type ReviewReleaser interface {
+	ReleaseReviews(teamName string, userIDs []string) (int, error)
+}
+
+type TeamServiceOption func(*TeamService)
+
+// WithReviewReleaser reassigns the open reviews of removed members and of
+// the members of deleted teams. Without it those reviews are kept.
+func WithReviewReleaser(releaser ReviewReleaser) TeamServiceOption {
+	return func(s *TeamService) {
+		s.reviewReleaser = releaser
+	}
 }
 
-func NewTeamService(teamRepository repository.TeamRepository) *TeamService {
-	return &TeamService{
+func NewTeamService(teamRepository repository.TeamRepository, opts ...TeamServiceOption) *TeamService {
+	s := &TeamService{
 		teamRepository: teamRepository,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *TeamService) GetTeamByName(teamName string) (*api.Team, error) {
 	if !s.teamRepository.ExistTeamByName(teamName) {
-		return nil, fmt.Errorf("team not found")
+		return nil, domain.ErrTeamNotFound
 	}
 	team := s.teamRepository.FindTeamByName(teamName)
 	if team.TeamName == "" {
-		return nil, fmt.Errorf("team not found")
+		return nil, domain.ErrTeamNotFound
 	}
 	return &team, nil
 }
 
 func (s *TeamService) AddTeam(team *api.Team) error {
 	if s.teamRepository.ExistTeamByName(team.TeamName) {
-		return fmt.Errorf("team already exists")
+		return domain.ErrTeamExists
+	}
+	if err := s.validateTeamSettings(team.TeamName, team.RequiredReviewers, team.SelectionStrategy, team.FallbackTeams, team.MaxOpenReviews, team.MinSeniorReviewers, team.ReviewTimeoutMinutes); err != nil {
+		return err
+	}
+	if err := validateSeniorities(team.Members); err != nil {
+		return err
 	}
+	if team.RequiredReviewers == nil {
+		requiredReviewers := defaultRequiredReviewers
+		team.RequiredReviewers = &requiredReviewers
+	}
+	team.IsArchived = false
 	return s.teamRepository.CreateTeam(*team)
 }
+
+// AddMembers adds users to the team. Their memberships in other teams, open
+// reviews and PRs are left as they are.
+func (s *TeamService) AddMembers(teamName string, members []api.TeamMember) (*api.Team, error) {
+	team, err := s.GetTeamByName(teamName)
+	if err != nil {
+		return nil, err
+	}
+	if team.IsArchived {
+		return nil, domain.ErrTeamArchived
+	}
+	if len(members) == 0 {
+		return nil, domain.ErrNoMembers
+	}
+	if err := validateSeniorities(members); err != nil {
+		return nil, err
+	}
+
+	if err := s.teamRepository.AddMembers(teamName, members); err != nil {
+		return nil, err
+	}
+	return s.GetTeamByName(teamName)
+}
+
+// RemoveMembers takes users out of the team. They stay active and keep their
+// other teams; their open reviews on the team's PRs go to the remaining
+// members or the fallback teams first, so a failed reassignment leaves the
+// membership in place. The second result is the number of reassigned
+// reviews.
+func (s *TeamService) RemoveMembers(teamName string, userIDs []string) (*api.Team, int, error) {
+	team, err := s.GetTeamByName(teamName)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(userIDs) == 0 {
+		return nil, 0, domain.ErrNoUserIDs
+	}
+	members := make(map[string]bool, len(team.Members))
+	for _, member := range team.Members {
+		members[member.UserId] = true
+	}
+	for _, userID := range userIDs {
+		if !members[userID] {
+			return nil, 0, domain.ErrNotTeamMember
+		}
+	}
+
+	reassigned, err := s.releaseReviews(teamName, userIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := s.teamRepository.RemoveMembers(teamName, userIDs); err != nil {
+		return nil, 0, err
+	}
+	team, err = s.GetTeamByName(teamName)
+	if err != nil {
+		return nil, 0, err
+	}
+	return team, reassigned, nil
+}
+
+// RenameTeam renames the team together with its memberships and the
+// fallback lists that refer to it.
+func (s *TeamService) RenameTeam(teamName string, newTeamName string) (*api.Team, error) {
+	if !s.teamRepository.ExistTeamByName(teamName) {
+		return nil, domain.ErrTeamNotFound
+	}
+	if newTeamName == "" || newTeamName == teamName {
+		return nil, domain.ErrInvalidTeamName
+	}
+	if s.teamRepository.ExistTeamByName(newTeamName) {
+		return nil, domain.ErrTeamExists
+	}
+
+	if err := s.teamRepository.RenameTeam(teamName, newTeamName); err != nil {
+		return nil, err
+	}
+	return s.GetTeamByName(newTeamName)
+}
+
+// ArchiveTeam stops the team from taking part in reviewer selection: its
+// members can no longer open PRs and are not picked as reviewers. Open PRs
+// keep their reviewers.
+func (s *TeamService) ArchiveTeam(teamName string) (*api.Team, error) {
+	if err := s.teamRepository.ArchiveTeam(teamName); err != nil {
+		return nil, err
+	}
+	return s.GetTeamByName(teamName)
+}
+
+// DeleteTeam reassigns the open reviews of all members on the team's PRs,
+// removes the team from other teams' fallback lists and deletes it. Members
+// keep their other teams. It returns the number of reassigned reviews.
+func (s *TeamService) DeleteTeam(teamName string) (int, error) {
+	team, err := s.GetTeamByName(teamName)
+	if err != nil {
+		return 0, err
+	}
+
+	userIDs := make([]string, 0, len(team.Members))
+	for _, member := range team.Members {
+		userIDs = append(userIDs, member.UserId)
+	}
+	reassigned, err := s.releaseReviews(teamName, userIDs)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.teamRepository.DeleteTeam(teamName); err != nil {
+		return 0, err
+	}
+	return reassigned, nil
+}
+
+func (s *TeamService) releaseReviews(teamName string, userIDs []string) (int, error) {
+	if s.reviewReleaser == nil || len(userIDs) == 0 {
+		return 0, nil
+	}
+	return s.reviewReleaser.ReleaseReviews(teamName, userIDs)
+}
+
+func (s *TeamService) UpdateTeamSettings(update api.PostTeamUpdateJSONRequestBody) (*api.Team, error) {
+	if !s.teamRepository.ExistTeamByName(update.TeamName) {
+		return nil, domain.ErrTeamNotFound
+	}
+	err := s.validateTeamSettings(update.TeamName, update.RequiredReviewers, update.SelectionStrategy, update.FallbackTeams, update.MaxOpenReviews, update.MinSeniorReviewers, update.ReviewTimeoutMinutes)
+	if err != nil {
+		return nil, err
+	}
+
+	team := s.teamRepository.FindTeamByName(update.TeamName)
+	if update.RequiredReviewers != nil {
+		team.RequiredReviewers = update.RequiredReviewers
+	}
+	if update.SelectionStrategy != nil {
+		team.SelectionStrategy = update.SelectionStrategy
+	}
+	if update.FallbackTeams != nil {
+		team.FallbackTeams = update.FallbackTeams
+	}
+	if update.MaxOpenReviews != nil {
+		team.MaxOpenReviews = update.MaxOpenReviews
+	}
+	if update.MinSeniorReviewers != nil {
+		team.MinSeniorReviewers = update.MinSeniorReviewers
+	}
+	if update.ReviewTimeoutMinutes != nil {
+		team.ReviewTimeoutMinutes = update.ReviewTimeoutMinutes
+	}
+
+	if err := s.teamRepository.UpdateTeam(team); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (s *TeamService) validateTeamSettings(
+	teamName string,
+	requiredReviewers *int,
+	strategy *api.TeamSelectionStrategy,
+	fallbackTeams *[]string,
+	maxOpenReviews *int,
+	minSeniorReviewers *int,
+	reviewTimeoutMinutes *int,
+) error {
+	if requiredReviewers != nil && *requiredReviewers < 1 {
+		return domain.ErrInvalidRequiredReviewers
+	}
+	if maxOpenReviews != nil && *maxOpenReviews < 1 {
+		return domain.ErrInvalidMaxOpenReviews
+	}
+	if minSeniorReviewers != nil && *minSeniorReviewers < 0 {
+		return domain.ErrInvalidMinSeniors
+	}
+	if reviewTimeoutMinutes != nil && *reviewTimeoutMinutes < 1 {
+		return domain.ErrInvalidReviewTimeout
+	}
+	if strategy != nil && !validSelectionStrategy(*strategy) {
+		return domain.ErrInvalidSelectionStrategy
+	}
+	if fallbackTeams != nil {
+		seen := make(map[string]bool)
+		for _, fallback := range *fallbackTeams {
+			if fallback == teamName || seen[fallback] || !s.teamRepository.ExistTeamByName(fallback) {
+				return domain.ErrInvalidFallbackTeams
+			}
+			seen[fallback] = true
+		}
+	}
+	return nil
+}
+
+func validateSeniorities(members []api.TeamMember) error {
+	for _, member := range members {
+		if member.Seniority != nil && !validSeniority(*member.Seniority) {
+			return domain.ErrInvalidSeniority
+		}
+	}
+	return nil
+}
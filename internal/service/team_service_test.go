@@ -1,9 +1,11 @@
 package service
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository/inmemory"
 )
 
@@ -89,3 +91,280 @@ func TestGetTeamByName(t *testing.T) {
 		t.Errorf("Expected team name 'frontend', got %s", retrievedTeam.TeamName)
 	}
 }
+
+func TestUpdateTeamSettings(t *testing.T) {
+	repo := inmemory.NewTeamRepository()
+	service := NewTeamService(repo)
+
+	_, err := service.UpdateTeamSettings(api.PostTeamUpdateJSONRequestBody{TeamName: "security"})
+	if err == nil {
+		t.Fatal("Expected error for non-existent team")
+	}
+
+	err = service.AddTeam(&api.Team{TeamName: "security", Members: []api.TeamMember{}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	requiredReviewers := 3
+	team, err := service.UpdateTeamSettings(api.PostTeamUpdateJSONRequestBody{
+		TeamName:          "security",
+		RequiredReviewers: &requiredReviewers,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if team.RequiredReviewers == nil || *team.RequiredReviewers != 3 {
+		t.Errorf("Expected required_reviewers 3, got %v", team.RequiredReviewers)
+	}
+
+	invalid := 0
+	_, err = service.UpdateTeamSettings(api.PostTeamUpdateJSONRequestBody{TeamName: "security", RequiredReviewers: &invalid})
+	if err == nil {
+		t.Fatal("Expected error for required_reviewers 0")
+	}
+}
+
+func TestUpdateTeamSettingsKeepsMembers(t *testing.T) {
+	f := newTeamLifecycleFixture(t)
+
+	stale := f.teamRepo.FindTeamByName("backend")
+	if err := f.userRepo.UpdateUserStatus("u2", false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	required := 3
+	stale.RequiredReviewers = &required
+	if err := f.teamRepo.UpdateTeam(stale); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	user, _ := f.userRepo.FindUserByID("u2")
+	if user.IsActive {
+		t.Error("Expected a settings update to keep u2 inactive")
+	}
+	backend := f.teamRepo.FindTeamByName("backend")
+	if len(backend.Members) != 4 || backend.RequiredReviewers == nil || *backend.RequiredReviewers != 3 {
+		t.Errorf("Unexpected team %+v", backend)
+	}
+}
+
+type teamLifecycleFixture struct {
+	teamRepo    *inmemory.TeamRepository
+	userRepo    *inmemory.UserRepository
+	prRepo      *inmemory.PullRequestRepository
+	prService   *PullRequestService
+	teamService *TeamService
+}
+
+// newTeamLifecycleFixture creates "backend" (u1..u4) with "platform" (u5) as
+// its fallback team and an open PR by u1 reviewed by u2 and u3.
+func newTeamLifecycleFixture(t *testing.T) teamLifecycleFixture {
+	t.Helper()
+
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepositoryWithUsers(userRepo)
+	prRepo := inmemory.NewPullRequestRepository()
+	teamRepo.LinkPullRequests(prRepo)
+	prService := NewPullRequestService(prRepo, teamRepo, userRepo)
+	teamService := NewTeamService(teamRepo, WithReviewReleaser(prService))
+
+	if err := teamService.AddTeam(&api.Team{TeamName: "platform", Members: []api.TeamMember{
+		{UserId: "u5", Username: "Eve", IsActive: true},
+	}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	fallbackTeams := []string{"platform"}
+	if err := teamService.AddTeam(&api.Team{TeamName: "backend", FallbackTeams: &fallbackTeams, Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+		{UserId: "u4", Username: "Dave", IsActive: true},
+	}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		TeamName:          "backend",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2", "u3"},
+	})
+
+	return teamLifecycleFixture{teamRepo, userRepo, prRepo, prService, teamService}
+}
+
+func TestRemoveMembersReassignsOpenReviews(t *testing.T) {
+	f := newTeamLifecycleFixture(t)
+
+	team, reassigned, err := f.teamService.RemoveMembers("backend", []string{"u2"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if reassigned != 1 || len(team.Members) != 3 {
+		t.Errorf("Expected 1 reassigned review and 3 members, got %d and %v", reassigned, team.Members)
+	}
+
+	pr, _ := f.prRepo.FindPRByID("pr-1")
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u3" || pr.AssignedReviewers[1] != "u4" {
+		t.Errorf("Expected reviewers [u3 u4], got %v", pr.AssignedReviewers)
+	}
+	history, _ := f.prService.GetHistory("pr-1")
+	last := history[len(history)-1]
+	if last.Action != api.ReviewerAssignmentActionTeamRemoval || *last.ReplacedUserId != "u2" {
+		t.Errorf("Expected team_removal entry replacing u2, got %+v", last)
+	}
+	user, _ := f.userRepo.FindUserByID("u2")
+	if len(user.Teams) != 0 || user.TeamName != "" || !user.IsActive {
+		t.Errorf("Expected u2 to stay active without a team, got %+v", user)
+	}
+
+	if _, _, err := f.teamService.RemoveMembers("backend", []string{"u5"}); !errors.Is(err, domain.ErrNotTeamMember) {
+		t.Errorf("Expected not a member error, got %v", err)
+	}
+}
+
+type failingReleaser struct{}
+
+func (failingReleaser) ReleaseReviews(string, []string) (int, error) {
+	return 0, errors.New("release failed")
+}
+
+func TestRemoveMembersKeepsMembershipWhenReleaseFails(t *testing.T) {
+	f := newTeamLifecycleFixture(t)
+	teamService := NewTeamService(f.teamRepo, WithReviewReleaser(failingReleaser{}))
+
+	if _, _, err := teamService.RemoveMembers("backend", []string{"u2"}); err == nil {
+		t.Fatal("Expected the release error")
+	}
+	team, _ := teamService.GetTeamByName("backend")
+	if len(team.Members) != 4 {
+		t.Errorf("Expected u2 to stay in backend, got %v", team.Members)
+	}
+}
+
+func TestAddMembersKeepsOtherTeams(t *testing.T) {
+	f := newTeamLifecycleFixture(t)
+
+	team, err := f.teamService.AddMembers("platform", []api.TeamMember{{UserId: "u4", Username: "Dave", IsActive: true}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(team.Members) != 2 {
+		t.Errorf("Expected 2 members, got %v", team.Members)
+	}
+	backend, _ := f.teamService.GetTeamByName("backend")
+	if len(backend.Members) != 4 {
+		t.Errorf("Expected u4 to stay in backend, got %v", backend.Members)
+	}
+	user, _ := f.userRepo.FindUserByID("u4")
+	if len(user.Teams) != 2 || user.Teams[0] != "backend" || user.Teams[1] != "platform" || user.TeamName != "backend" {
+		t.Errorf("Expected u4 in backend and platform, got %+v", user)
+	}
+	teams, _ := f.teamRepo.FindTeamsByUser("u4")
+	if len(teams) != 2 {
+		t.Errorf("Expected 2 teams, got %v", teams)
+	}
+}
+
+func TestRenameTeamKeepsMembersAndFallbackReferences(t *testing.T) {
+	f := newTeamLifecycleFixture(t)
+
+	if _, err := f.teamService.RenameTeam("platform", "backend"); !errors.Is(err, domain.ErrTeamExists) {
+		t.Errorf("Expected team exists error, got %v", err)
+	}
+	team, err := f.teamService.RenameTeam("platform", "infra")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if team.TeamName != "infra" || len(team.Members) != 1 {
+		t.Errorf("Unexpected team %+v", team)
+	}
+	if f.teamRepo.ExistTeamByName("platform") {
+		t.Error("Expected old name to be gone")
+	}
+	backend, _ := f.teamService.GetTeamByName("backend")
+	if backend.FallbackTeams == nil || (*backend.FallbackTeams)[0] != "infra" {
+		t.Errorf("Expected fallback to point at infra, got %v", backend.FallbackTeams)
+	}
+	user, _ := f.userRepo.FindUserByID("u5")
+	if user.TeamName != "infra" {
+		t.Errorf("Expected u5 in infra, got %s", user.TeamName)
+	}
+
+	minSeniors, reviewTimeout := 1, 240
+	if _, err := f.teamService.UpdateTeamSettings(api.PostTeamUpdateJSONRequestBody{
+		TeamName: "backend", MinSeniorReviewers: &minSeniors, ReviewTimeoutMinutes: &reviewTimeout,
+	}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	core, err := f.teamService.RenameTeam("backend", "core")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if core.MinSeniorReviewers == nil || *core.MinSeniorReviewers != 1 {
+		t.Errorf("Expected the seniority policy to survive the rename, got %v", core.MinSeniorReviewers)
+	}
+	if core.ReviewTimeoutMinutes == nil || *core.ReviewTimeoutMinutes != 240 {
+		t.Errorf("Expected the review SLA to survive the rename, got %v", core.ReviewTimeoutMinutes)
+	}
+	pr, _ := f.prRepo.FindPRByID("pr-1")
+	if pr.TeamName != "core" {
+		t.Errorf("Expected pr-1 to move to core, got %s", pr.TeamName)
+	}
+}
+
+func TestArchivedTeamTakesNoPartInSelection(t *testing.T) {
+	f := newTeamLifecycleFixture(t)
+
+	if _, err := f.teamService.ArchiveTeam("platform"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := f.prService.CreatePR(&api.PullRequest{PullRequestId: "pr-2", AuthorId: "u5"}, CreatePROptions{}); !errors.Is(err, domain.ErrTeamArchived) {
+		t.Errorf("Expected team archived error, got %v", err)
+	}
+	if _, err := f.teamService.AddMembers("platform", []api.TeamMember{{UserId: "u6", Username: "Frank", IsActive: true}}); !errors.Is(err, domain.ErrTeamArchived) {
+		t.Errorf("Expected team archived error, got %v", err)
+	}
+
+	if _, _, err := f.teamService.RemoveMembers("backend", []string{"u4"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, _, err := f.prService.ReassignReviewer("pr-1", "u2")
+	if !errors.Is(err, domain.ErrNoReplacementCandidate) {
+		t.Errorf("Expected no candidate from the archived fallback team, got %v", err)
+	}
+}
+
+func TestDeleteTeamReassignsReviewsToFallbackTeams(t *testing.T) {
+	f := newTeamLifecycleFixture(t)
+	_ = f.prRepo.CreatePR(api.PullRequest{
+		PullRequestId:     "pr-2",
+		AuthorId:          "u5",
+		TeamName:          "platform",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2"},
+	})
+
+	reassigned, err := f.teamService.DeleteTeam("backend")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if reassigned != 2 {
+		t.Errorf("Expected 2 released reviews, got %d", reassigned)
+	}
+	pr, _ := f.prRepo.FindPRByID("pr-1")
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u5" {
+		t.Errorf("Expected only the fallback reviewer u5, got %v", pr.AssignedReviewers)
+	}
+	other, _ := f.prRepo.FindPRByID("pr-2")
+	if len(other.AssignedReviewers) != 1 || other.AssignedReviewers[0] != "u2" {
+		t.Errorf("Expected the platform PR to keep u2, got %v", other.AssignedReviewers)
+	}
+	if f.teamRepo.ExistTeamByName("backend") {
+		t.Error("Expected backend to be deleted")
+	}
+	user, _ := f.userRepo.FindUserByID("u1")
+	if len(user.Teams) != 0 {
+		t.Errorf("Expected u1 without a team, got %v", user.Teams)
+	}
+}
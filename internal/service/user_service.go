@@ -1,30 +1,288 @@
 package service
 
 import (
-	"fmt"
+	"errors"
+	"sort"
+	"strings"
 
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
 )
 
 type UserService struct {
-	userRepository repository.UserRepository
+	userRepository   repository.UserRepository
+	teamRepository   repository.TeamRepository
+	reviewReassigner ReviewReassigner
 }
 
-func NewUserService(userRepository repository.UserRepository) *UserService {
-	return &UserService{
+// ReviewReassigner hands the open reviews of a user over to other
+// reviewers, either for the PRs of one team or for all of them.
+// PullRequestService implements it.
+type ReviewReassigner interface {
+	ReviewReleaser
+	HandOffReviews(userID string) (int, error)
+}
+
+type UserServiceOption func(*UserService)
+
+// WithReviewReassigner reassigns the open reviews of users that leave a
+// team or are offboarded. Without it those reviews are kept.
+func WithReviewReassigner(reassigner ReviewReassigner) UserServiceOption {
+	return func(s *UserService) {
+		s.reviewReassigner = reassigner
+	}
+}
+
+func NewUserService(userRepository repository.UserRepository, teamRepository repository.TeamRepository, opts ...UserServiceOption) *UserService {
+	s := &UserService{
 		userRepository: userRepository,
+		teamRepository: teamRepository,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *UserService) CreateUser(user api.User) (*api.User, error) {
+	if user.UserId == "" || user.Username == "" {
+		return nil, domain.ErrInvalidUser
+	}
+	if user.MaxOpenReviews != nil && *user.MaxOpenReviews < 1 {
+		return nil, domain.ErrInvalidMaxOpenReviews
+	}
+	if user.Seniority != nil && !validSeniority(*user.Seniority) {
+		return nil, domain.ErrInvalidSeniority
+	}
+	if user.Tags != nil {
+		tags, err := normalizeTags(*user.Tags)
+		if err != nil {
+			return nil, err
+		}
+		user.Tags = &tags
+	}
+	teams, err := s.validateTeams(user.Teams, nil)
+	if err != nil {
+		return nil, err
+	}
+	user.Teams = teams
+
+	if err := s.userRepository.CreateUser(user); err != nil {
+		return nil, err
 	}
+	return s.userRepository.FindUserByID(user.UserId)
 }
 
 func (s *UserService) GetUserByID(userID string) (*api.User, error) {
 	return s.userRepository.FindUserByID(userID)
 }
 
+// ListUsers returns the users ordered by user_id, limited to the members of
+// teamName, to the users tagged with tag and to isActive when they are given.
+func (s *UserService) ListUsers(teamName string, tag string, isActive *bool) ([]api.User, error) {
+	if teamName != "" && !s.teamRepository.ExistTeamByName(teamName) {
+		return nil, domain.ErrTeamNotFound
+	}
+	return s.userRepository.ListUsers(repository.UserFilter{
+		TeamName: teamName,
+		Tag:      strings.ToLower(strings.TrimSpace(tag)),
+		IsActive: isActive,
+	})
+}
+
+// UpdateUser changes the username, open review limit and seniority and
+// replaces the teams and tags of a user when they are given. Open reviews on
+// the PRs of teams the user leaves are reassigned; the second result is their
+// number.
+func (s *UserService) UpdateUser(update api.PostUsersUpdateJSONRequestBody) (*api.User, int, error) {
+	userID := update.UserId
+	user, err := s.userRepository.FindUserByID(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	previousTeams := append([]string{}, user.Teams...)
+	updated := *user
+	if update.Username != nil {
+		if *update.Username == "" {
+			return nil, 0, domain.ErrInvalidUser
+		}
+		updated.Username = *update.Username
+	}
+	if update.MaxOpenReviews != nil {
+		if *update.MaxOpenReviews < 1 {
+			return nil, 0, domain.ErrInvalidMaxOpenReviews
+		}
+		updated.MaxOpenReviews = update.MaxOpenReviews
+	}
+	if update.Seniority != nil {
+		if !validSeniority(*update.Seniority) {
+			return nil, 0, domain.ErrInvalidSeniority
+		}
+		updated.Seniority = update.Seniority
+	}
+	if update.Tags != nil {
+		normalized, err := normalizeTags(*update.Tags)
+		if err != nil {
+			return nil, 0, err
+		}
+		updated.Tags = &normalized
+	}
+	if update.Teams != nil {
+		updated.Teams, err = s.validateTeams(*update.Teams, previousTeams)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if err := s.userRepository.UpdateUser(updated); err != nil {
+		return nil, 0, err
+	}
+
+	reassigned := 0
+	for _, teamName := range previousTeams {
+		if memberOf(&updated, teamName) {
+			continue
+		}
+		released, err := s.releaseReviews(teamName, userID)
+		if err != nil {
+			return nil, reassigned, err
+		}
+		reassigned += released
+	}
+
+	result, err := s.userRepository.FindUserByID(userID)
+	return result, reassigned, err
+}
+
+// OffboardUser deactivates a user, hands off all of their open reviews and
+// removes them from every team.
+func (s *UserService) OffboardUser(userID string) (*api.User, int, error) {
+	user, err := s.userRepository.FindUserByID(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := s.userRepository.UpdateUserStatus(userID, false); err != nil {
+		return nil, 0, err
+	}
+
+	reassigned := 0
+	if s.reviewReassigner != nil {
+		reassigned, err = s.reviewReassigner.HandOffReviews(userID)
+		if err != nil {
+			return nil, reassigned, err
+		}
+	}
+
+	offboarded := *user
+	offboarded.IsActive = false
+	offboarded.Teams = []string{}
+	if err := s.userRepository.UpdateUser(offboarded); err != nil {
+		return nil, reassigned, err
+	}
+
+	result, err := s.userRepository.FindUserByID(userID)
+	return result, reassigned, err
+}
+
+// AddAwayPeriod registers a period in which the user gets no new reviews.
+// With HandOffReviews their open reviews are handed off once it starts,
+// preferably to DelegateId.
+func (s *UserService) AddAwayPeriod(period api.AwayPeriod) (*api.AwayPeriod, error) {
+	if !period.EndsAt.After(period.StartsAt) {
+		return nil, domain.ErrInvalidAwayPeriod
+	}
+	if _, err := s.userRepository.FindUserByID(period.UserId); err != nil {
+		return nil, err
+	}
+	if period.DelegateId != nil {
+		if *period.DelegateId == period.UserId {
+			return nil, domain.ErrInvalidDelegate
+		}
+		_, err := s.userRepository.FindUserByID(*period.DelegateId)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrDelegateNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	period.HandedOffAt = nil
+	return s.userRepository.CreateAwayPeriod(period)
+}
+
+// ListAwayPeriods returns the away periods of a user ordered by start.
+func (s *UserService) ListAwayPeriods(userID string) ([]api.AwayPeriod, error) {
+	if _, err := s.userRepository.FindUserByID(userID); err != nil {
+		return nil, err
+	}
+	return s.userRepository.FindAwayPeriodsByUser(userID)
+}
+
+// DeleteAwayPeriod removes an away period, which ends it early when it has
+// already started. Reviews that were handed off stay with their new
+// reviewers.
+func (s *UserService) DeleteAwayPeriod(periodID int64) error {
+	return s.userRepository.DeleteAwayPeriod(periodID)
+}
+
+func (s *UserService) releaseReviews(teamName string, userID string) (int, error) {
+	if s.reviewReassigner == nil || !s.teamRepository.ExistTeamByName(teamName) {
+		return 0, nil
+	}
+	return s.reviewReassigner.ReleaseReviews(teamName, []string{userID})
+}
+
+// validateTeams drops duplicates and checks that every team exists. Teams
+// the user is not already in must not be archived.
+func (s *UserService) validateTeams(teams []string, current []string) ([]string, error) {
+	kept := make(map[string]bool, len(current))
+	for _, teamName := range current {
+		kept[teamName] = true
+	}
+
+	result := []string{}
+	seen := make(map[string]bool, len(teams))
+	for _, teamName := range teams {
+		if seen[teamName] {
+			continue
+		}
+		seen[teamName] = true
+
+		if !s.teamRepository.ExistTeamByName(teamName) {
+			return nil, domain.ErrTeamNotFound
+		}
+		if !kept[teamName] && s.teamRepository.FindTeamByName(teamName).IsArchived {
+			return nil, domain.ErrTeamArchived
+		}
+		result = append(result, teamName)
+	}
+	return result, nil
+}
+
+// normalizeTags lowercases and trims tags, drops duplicates and sorts them.
+// Empty tags are rejected.
+func normalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	result := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			return nil, domain.ErrInvalidTags
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			result = append(result, tag)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
 func (s *UserService) SetUserStatus(userID string, status bool) (*api.User, error) {
 	user, err := s.userRepository.FindUserByID(userID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, err
 	}
 
 	err = s.userRepository.UpdateUserStatus(userID, status)
@@ -1,16 +1,18 @@
 package service
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
 	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository/inmemory"
 )
 
 func TestGetUserByID(t *testing.T) {
 	repo := inmemory.NewUserRepository()
 	repo.AddUser(&api.User{UserId: "u1", Username: "Alice", IsActive: true, TeamName: "backend"})
-	service := NewUserService(repo)
+	service := NewUserService(repo, inmemory.NewTeamRepository())
 
 	user, err := service.GetUserByID("u1")
 	if err != nil {
@@ -34,7 +36,7 @@ func TestGetUserByID(t *testing.T) {
 func TestSetUserStatus(t *testing.T) {
 	repo := inmemory.NewUserRepository()
 	repo.AddUser(&api.User{UserId: "u2", Username: "Bob", IsActive: true, TeamName: "backend"})
-	service := NewUserService(repo)
+	service := NewUserService(repo, inmemory.NewTeamRepository())
 
 	user, err := service.SetUserStatus("u2", false)
 	if err != nil {
@@ -54,3 +56,106 @@ func TestSetUserStatus(t *testing.T) {
 		t.Error("Expected user to be active")
 	}
 }
+
+func newUserManagementFixture(t *testing.T) (*UserService, *inmemory.UserRepository, *inmemory.PullRequestRepository) {
+	t.Helper()
+
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepositoryWithUsers(userRepo)
+	prRepo := inmemory.NewPullRequestRepository()
+	prService := NewPullRequestService(prRepo, teamRepo, userRepo)
+
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+		{UserId: "u3", Username: "Charlie", IsActive: true},
+	}})
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "security", Members: []api.TeamMember{
+		{UserId: "u4", Username: "Diana", IsActive: true},
+	}})
+	_ = prRepo.CreatePR(api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		TeamName:          "backend",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2"},
+	})
+
+	return NewUserService(userRepo, teamRepo, WithReviewReassigner(prService)), userRepo, prRepo
+}
+
+func TestCreateAndListUsers(t *testing.T) {
+	service, _, _ := newUserManagementFixture(t)
+
+	user, err := service.CreateUser(api.User{UserId: "u5", Username: "Eve", IsActive: true, Teams: []string{"security", "backend", "security"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(user.Teams) != 2 || user.TeamName != "security" {
+		t.Errorf("Expected teams [security backend], got %+v", user)
+	}
+
+	if _, err := service.CreateUser(api.User{UserId: "u5", Username: "Eve"}); !errors.Is(err, domain.ErrUserExists) {
+		t.Errorf("Expected user exists error, got %v", err)
+	}
+	if _, err := service.CreateUser(api.User{UserId: "u6", Username: "Frank", Teams: []string{"nope"}}); !errors.Is(err, domain.ErrTeamNotFound) {
+		t.Errorf("Expected team not found error, got %v", err)
+	}
+
+	users, err := service.ListUsers("security", "", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(users) != 2 || users[0].UserId != "u4" || users[1].UserId != "u5" {
+		t.Errorf("Expected security members [u4 u5], got %v", users)
+	}
+
+	_, _ = service.SetUserStatus("u2", false)
+	inactive := false
+	users, _ = service.ListUsers("", "", &inactive)
+	if len(users) != 1 || users[0].UserId != "u2" {
+		t.Errorf("Expected only u2 inactive, got %v", users)
+	}
+}
+
+func TestUpdateUserReleasesReviewsOfLeftTeams(t *testing.T) {
+	service, _, prRepo := newUserManagementFixture(t)
+
+	username := "Robert"
+	teams := []string{"security"}
+	user, reassigned, err := service.UpdateUser(api.PostUsersUpdateJSONRequestBody{UserId: "u2", Username: &username, Teams: &teams})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Username != "Robert" || len(user.Teams) != 1 || user.TeamName != "security" {
+		t.Errorf("Unexpected user %+v", user)
+	}
+	if reassigned != 1 {
+		t.Errorf("Expected 1 released review, got %d", reassigned)
+	}
+	pr, _ := prRepo.FindPRByID("pr-1")
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u3" {
+		t.Errorf("Expected u3 to take over, got %v", pr.AssignedReviewers)
+	}
+}
+
+func TestOffboardUserHandsOffReviews(t *testing.T) {
+	service, _, prRepo := newUserManagementFixture(t)
+
+	user, reassigned, err := service.OffboardUser("u2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.IsActive || len(user.Teams) != 0 || reassigned != 1 {
+		t.Errorf("Expected inactive user without teams and 1 reassigned review, got %+v, %d", user, reassigned)
+	}
+	pr, _ := prRepo.FindPRByID("pr-1")
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u3" {
+		t.Errorf("Expected u3 to take over, got %v", pr.AssignedReviewers)
+	}
+
+	users, _ := service.ListUsers("backend", "", nil)
+	if len(users) != 2 {
+		t.Errorf("Expected u2 to leave backend, got %v", users)
+	}
+}
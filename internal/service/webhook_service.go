@@ -0,0 +1,141 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository"
+)
+
+type PullRequestEventAction string
+
+const (
+	PullRequestEventOpened         PullRequestEventAction = "opened"
+	PullRequestEventReopened       PullRequestEventAction = "reopened"
+	PullRequestEventReadyForReview PullRequestEventAction = "ready_for_review"
+	PullRequestEventMerged         PullRequestEventAction = "merged"
+	PullRequestEventClosed         PullRequestEventAction = "closed"
+)
+
+// PullRequestEvent is a pull request event received from a git hosting
+// provider, already translated from the provider's payload.
+type PullRequestEvent struct {
+	Source          string
+	DeliveryID      string
+	Action          PullRequestEventAction
+	PullRequestID   string
+	PullRequestName string
+	Repository      string
+	AuthorLogin     string
+	Draft           bool
+}
+
+type WebhookService struct {
+	prService          *PullRequestService
+	userRepository     repository.UserRepository
+	deliveryRepository repository.WebhookDeliveryRepository
+	usernames          map[string]string
+}
+
+// NewWebhookService creates a service that applies provider events.
+// usernames maps external logins (case-insensitive) to user_id; a login
+// without a mapping is used as the user_id itself.
+func NewWebhookService(
+	prService *PullRequestService,
+	userRepository repository.UserRepository,
+	deliveryRepository repository.WebhookDeliveryRepository,
+	usernames map[string]string,
+) *WebhookService {
+	normalized := make(map[string]string, len(usernames))
+	for login, userID := range usernames {
+		normalized[strings.ToLower(login)] = userID
+	}
+	return &WebhookService{
+		prService:          prService,
+		userRepository:     userRepository,
+		deliveryRepository: deliveryRepository,
+		usernames:          normalized,
+	}
+}
+
+// HandlePullRequestEvent applies the event once per delivery. A repeated
+// delivery returns the "delivery already exists" error; a delivery that
+// failed is forgotten so that the provider can retry it.
+func (s *WebhookService) HandlePullRequestEvent(event PullRequestEvent) (*api.PullRequest, error) {
+	if event.DeliveryID != "" {
+		if err := s.deliveryRepository.SaveDelivery(event.Source, event.DeliveryID); err != nil {
+			return nil, err
+		}
+	}
+
+	pr, err := s.applyPullRequestEvent(event)
+	if err != nil && event.DeliveryID != "" {
+		_ = s.deliveryRepository.DeleteDelivery(event.Source, event.DeliveryID)
+	}
+	return pr, err
+}
+
+func (s *WebhookService) applyPullRequestEvent(event PullRequestEvent) (*api.PullRequest, error) {
+	existing, _ := s.prService.FindPRByID(event.PullRequestID)
+	switch event.Action {
+	case PullRequestEventOpened:
+		if existing != nil {
+			return existing, nil
+		}
+		return s.createPR(event)
+	case PullRequestEventReopened:
+		if existing == nil {
+			return s.createPR(event)
+		}
+		return s.prService.ReopenPR(event.PullRequestID)
+	case PullRequestEventReadyForReview:
+		if existing == nil {
+			event.Draft = false
+			return s.createPR(event)
+		}
+		return s.prService.ReadyForReview(event.PullRequestID, CreatePROptions{Repository: event.Repository})
+	case PullRequestEventMerged:
+		// The provider has already merged it, approvals are its business.
+		return s.prService.MergePR(event.PullRequestID, true)
+	case PullRequestEventClosed:
+		return s.prService.ClosePR(event.PullRequestID)
+	default:
+		return nil, fmt.Errorf("unsupported event action %q", event.Action)
+	}
+}
+
+// createPR creates the PR of an event for a PR the service has not seen yet.
+func (s *WebhookService) createPR(event PullRequestEvent) (*api.PullRequest, error) {
+	authorID, err := s.ResolveUser(event.AuthorLogin)
+	if err != nil {
+		return nil, err
+	}
+	pr := &api.PullRequest{
+		PullRequestId:   event.PullRequestID,
+		PullRequestName: event.PullRequestName,
+		AuthorId:        authorID,
+	}
+	if err := s.prService.CreatePR(pr, CreatePROptions{Repository: event.Repository, Draft: event.Draft}); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// ResolveUser maps an external login to a user_id.
+func (s *WebhookService) ResolveUser(login string) (string, error) {
+	userID, ok := s.usernames[strings.ToLower(login)]
+	if !ok {
+		userID = login
+	}
+	_, err := s.userRepository.FindUserByID(userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", domain.ErrAuthorNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return userID, nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/api"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/domain"
+	"github.com/romreign/PR-Reviewer-Assignment-Service/internal/repository/inmemory"
+)
+
+func TestWebhookResolveUser(t *testing.T) {
+	userRepo := inmemory.NewUserRepository()
+	userRepo.AddUser(&api.User{UserId: "u1", Username: "Alice", IsActive: true, TeamName: "backend"})
+	prService := NewPullRequestService(inmemory.NewPullRequestRepository(), inmemory.NewTeamRepository(), userRepo)
+	service := NewWebhookService(prService, userRepo, inmemory.NewWebhookDeliveryRepository(), map[string]string{"Alice-GH": "u1"})
+
+	if userID, err := service.ResolveUser("alice-gh"); err != nil || userID != "u1" {
+		t.Errorf("Expected u1, got %q (%v)", userID, err)
+	}
+	if userID, err := service.ResolveUser("u1"); err != nil || userID != "u1" {
+		t.Errorf("Expected unmapped login to be used as user_id, got %q (%v)", userID, err)
+	}
+	if _, err := service.ResolveUser("mallory"); !errors.Is(err, domain.ErrAuthorNotFound) {
+		t.Errorf("Expected 'author not found' error, got %v", err)
+	}
+}
+
+func TestWebhookFailedDeliveryCanBeRetried(t *testing.T) {
+	userRepo := inmemory.NewUserRepository()
+	teamRepo := inmemory.NewTeamRepository()
+	prService := NewPullRequestService(inmemory.NewPullRequestRepository(), teamRepo, userRepo)
+	service := NewWebhookService(prService, userRepo, inmemory.NewWebhookDeliveryRepository(), nil)
+
+	event := PullRequestEvent{
+		Source:          "github",
+		DeliveryID:      "d1",
+		Action:          PullRequestEventOpened,
+		PullRequestID:   "acme/api#1",
+		PullRequestName: "Test PR",
+		AuthorLogin:     "u1",
+	}
+	if _, err := service.HandlePullRequestEvent(event); err == nil {
+		t.Fatal("Expected error for unknown author")
+	}
+
+	_ = teamRepo.CreateTeam(api.Team{TeamName: "backend", Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+	}})
+	userRepo.AddUser(&api.User{UserId: "u1", Username: "Alice", IsActive: true, TeamName: "backend"})
+
+	pr, err := service.HandlePullRequestEvent(event)
+	if err != nil {
+		t.Fatalf("Expected retried delivery to succeed, got %v", err)
+	}
+	if pr.AuthorId != "u1" {
+		t.Errorf("Expected author u1, got %s", pr.AuthorId)
+	}
+
+	if _, err := service.HandlePullRequestEvent(event); !errors.Is(err, domain.ErrDeliveryExists) {
+		t.Errorf("Expected 'delivery already exists' error, got %v", err)
+	}
+}
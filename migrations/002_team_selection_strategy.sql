@@ -0,0 +1,3 @@
+ALTER TABLE teams
+  ADD COLUMN IF NOT EXISTS selection_strategy TEXT
+    CHECK (selection_strategy IN ('random', 'round_robin', 'least_loaded'));
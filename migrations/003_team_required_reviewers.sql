@@ -0,0 +1,3 @@
+ALTER TABLE teams
+  ADD COLUMN IF NOT EXISTS required_reviewers INTEGER
+    CHECK (required_reviewers >= 1);
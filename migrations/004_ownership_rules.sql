@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS ownership_rules (
+  repository TEXT NOT NULL,
+  position INTEGER NOT NULL,
+  pattern TEXT NOT NULL,
+  owners TEXT[] NOT NULL DEFAULT '{}',
+  PRIMARY KEY (repository, position)
+);
@@ -0,0 +1,5 @@
+ALTER TABLE teams
+  ADD COLUMN IF NOT EXISTS fallback_teams TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE pr_reviewers
+  ADD COLUMN IF NOT EXISTS from_fallback BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  source TEXT NOT NULL,
+  delivery_id TEXT NOT NULL,
+  received_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (source, delivery_id)
+);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS reviewer_assignments (
+  id BIGSERIAL PRIMARY KEY,
+  pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL REFERENCES users(user_id),
+  action TEXT NOT NULL CHECK (action IN ('create', 'reassign', 'deactivation', 'manual')),
+  replaced_user_id TEXT REFERENCES users(user_id),
+  assigned_at TIMESTAMP NOT NULL,
+  unassigned_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_assignments_pull_request_id ON reviewer_assignments(pull_request_id);
+
+-- Reviewers assigned before the history existed.
+INSERT INTO reviewer_assignments (pull_request_id, user_id, action, assigned_at)
+SELECT r.pull_request_id, r.user_id, 'manual', COALESCE(p.created_at, NOW())
+FROM pr_reviewers r
+JOIN pull_requests p ON p.pull_request_id = r.pull_request_id
+WHERE NOT EXISTS (SELECT 1 FROM reviewer_assignments a WHERE a.pull_request_id = r.pull_request_id);
@@ -0,0 +1,8 @@
+ALTER TABLE teams
+  ADD COLUMN IF NOT EXISTS is_archived BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE reviewer_assignments
+  DROP CONSTRAINT IF EXISTS reviewer_assignments_action_check;
+ALTER TABLE reviewer_assignments
+  ADD CONSTRAINT reviewer_assignments_action_check
+    CHECK (action IN ('create', 'reassign', 'deactivation', 'manual', 'team_removal'));
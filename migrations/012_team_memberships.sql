@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS team_memberships (
+  team_name TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+  user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+  joined_at TIMESTAMP NOT NULL DEFAULT now(),
+  PRIMARY KEY(team_name, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_memberships_user_id ON team_memberships(user_id);
+
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS team_name TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_team_name ON pull_requests(team_name);
+
+-- Move the single users.team_name into the join table and give existing PRs
+-- the author's team. Skipped once the column is gone.
+DO $$
+BEGIN
+  IF EXISTS (
+    SELECT 1 FROM information_schema.columns
+    WHERE table_name = 'users' AND column_name = 'team_name'
+  ) THEN
+    INSERT INTO team_memberships (team_name, user_id)
+    SELECT team_name, user_id FROM users WHERE team_name IS NOT NULL
+    ON CONFLICT DO NOTHING;
+
+    UPDATE pull_requests pr SET team_name = u.team_name
+    FROM users u
+    WHERE u.user_id = pr.author_id AND pr.team_name IS NULL;
+
+    DROP INDEX IF EXISTS idx_users_team_name;
+    ALTER TABLE users DROP COLUMN team_name;
+  END IF;
+END $$;
@@ -0,0 +1,2 @@
+-- Keyset pages of /pullRequest/list sorted by creation time.
+CREATE INDEX IF NOT EXISTS idx_pull_requests_created_at ON pull_requests(created_at, pull_request_id);
@@ -0,0 +1,14 @@
+ALTER TABLE pull_requests
+  DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+ALTER TABLE pull_requests
+  ADD CONSTRAINT pull_requests_status_check
+    CHECK (status IN ('OPEN', 'MERGED', 'CLOSED'));
+
+ALTER TABLE pull_requests
+  ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP;
+
+ALTER TABLE reviewer_assignments
+  DROP CONSTRAINT IF EXISTS reviewer_assignments_action_check;
+ALTER TABLE reviewer_assignments
+  ADD CONSTRAINT reviewer_assignments_action_check
+    CHECK (action IN ('create', 'reassign', 'deactivation', 'manual', 'team_removal', 'reopen'));
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS review_decisions (
+  id BIGSERIAL PRIMARY KEY,
+  pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL REFERENCES users(user_id),
+  decision TEXT NOT NULL CHECK (decision IN ('approved', 'changes_requested', 'commented')),
+  submitted_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_decisions_pull_request_id ON review_decisions(pull_request_id);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS review_escalations (
+  id BIGSERIAL PRIMARY KEY,
+  pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL REFERENCES users(user_id),
+  stage TEXT NOT NULL CHECK (stage IN ('reminder', 'reassignment')),
+  new_user_id TEXT REFERENCES users(user_id),
+  escalated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_escalations_pull_request_id ON review_escalations(pull_request_id);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS away_periods (
+  id BIGSERIAL PRIMARY KEY,
+  user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+  starts_at TIMESTAMP NOT NULL,
+  ends_at TIMESTAMP NOT NULL,
+  delegate_id TEXT REFERENCES users(user_id) ON DELETE SET NULL,
+  hand_off_reviews BOOLEAN NOT NULL DEFAULT FALSE,
+  handed_off_at TIMESTAMP,
+  CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_away_periods_user_id ON away_periods(user_id);
+CREATE INDEX IF NOT EXISTS idx_away_periods_range ON away_periods(starts_at, ends_at);
+
+ALTER TABLE reviewer_assignments
+  DROP CONSTRAINT IF EXISTS reviewer_assignments_action_check;
+ALTER TABLE reviewer_assignments
+  ADD CONSTRAINT reviewer_assignments_action_check
+    CHECK (action IN ('create', 'reassign', 'deactivation', 'manual', 'team_removal', 'reopen', 'away'));
@@ -0,0 +1,19 @@
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS max_open_reviews INT CHECK (max_open_reviews >= 1);
+
+ALTER TABLE teams
+  ADD COLUMN IF NOT EXISTS max_open_reviews INT CHECK (max_open_reviews >= 1);
+
+ALTER TABLE pr_reviewers
+  ADD COLUMN IF NOT EXISTS over_capacity BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE pull_requests
+  ADD COLUMN IF NOT EXISTS is_queued BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_queued ON pull_requests(created_at) WHERE is_queued;
+
+ALTER TABLE reviewer_assignments
+  DROP CONSTRAINT IF EXISTS reviewer_assignments_action_check;
+ALTER TABLE reviewer_assignments
+  ADD CONSTRAINT reviewer_assignments_action_check
+    CHECK (action IN ('create', 'reassign', 'deactivation', 'manual', 'team_removal', 'reopen', 'away', 'queue'));
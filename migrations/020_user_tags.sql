@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS user_tags (
+  user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+  tag TEXT NOT NULL CHECK (tag <> ''),
+  PRIMARY KEY (user_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tags_tag ON user_tags(tag);
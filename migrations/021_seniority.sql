@@ -0,0 +1,5 @@
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS seniority TEXT CHECK (seniority IN ('junior', 'middle', 'senior', 'lead'));
+
+ALTER TABLE teams
+  ADD COLUMN IF NOT EXISTS min_senior_reviewers INT CHECK (min_senior_reviewers >= 0);
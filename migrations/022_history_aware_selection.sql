@@ -0,0 +1,7 @@
+ALTER TABLE teams
+  DROP CONSTRAINT IF EXISTS teams_selection_strategy_check;
+ALTER TABLE teams
+  ADD CONSTRAINT teams_selection_strategy_check
+    CHECK (selection_strategy IN ('random', 'round_robin', 'least_loaded', 'history_aware'));
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_assignments_user_assigned_at ON reviewer_assignments(user_id, assigned_at);
@@ -0,0 +1,2 @@
+ALTER TABLE teams
+  ADD COLUMN IF NOT EXISTS review_timeout_minutes INT CHECK (review_timeout_minutes >= 1);